@@ -2,7 +2,8 @@ import { h, Component } from "preact";
 import { DateTime } from "luxon";
 
 import humanizer from "../duration";
-import { GetClosestStations } from "../rpc";
+import { StreamClosestStations } from "../rpc";
+import { pushSupported, savedStations, saveStation } from "../push";
 import css from "./mta.css";
 
 // Times Square - 42 St.
@@ -11,20 +12,39 @@ const defaultLatLon = { Lat: 40.7589545, Lon: -73.9849801 };
 class MTA extends Component {
   constructor() {
     super();
-    this.state = { stations: [] };
+    this.state = { stations: [], saved: savedStations() };
+  }
+
+  // toggleSaved saves a station for notifications of its next trains and
+  // the status of its routes, or forgets it.
+  toggleSaved(station) {
+    let routes = null;
+    if (!this.state.saved[station.ID]) {
+      const arrivals = Object.values(station.Arrivals || {});
+      routes = [...new Set([].concat(...arrivals).map(v => v.RouteID))];
+    }
+    saveStation(station.ID, routes)
+      .then(saved => this.setState({ saved: saved }))
+      .catch(err => console.error(err));
   }
 
   refreshFeed(coordinates) {
-    GetClosestStations(coordinates || defaultLatLon).then(stations => {
+    if (this.source) this.source.close();
+    this.setState({ stations: [] });
+    this.source = StreamClosestStations(coordinates || defaultLatLon, station => {
+      const stations = this.state.stations.slice();
+      const i = stations.findIndex(v => v.ID === station.ID);
+      if (i === -1) {
+        stations.push(station);
+      } else {
+        stations[i] = station;
+      }
       this.setState({ stations: stations });
     });
   }
 
   componentDidMount() {
     this.refreshFeed(this.props.coordinates);
-    this.feedInterval = setInterval(() => {
-      this.refreshFeed(this.props.coordinates);
-    }, 30000);
   }
 
   componentWillReceiveProps(nextProps) {
@@ -34,7 +54,7 @@ class MTA extends Component {
   }
 
   componentWillUnmount() {
-    clearInterval(this.feedInterval);
+    if (this.source) this.source.close();
   }
 
   renderArrival(header, trips = []) {
@@ -80,6 +100,11 @@ class MTA extends Component {
       <pre className={css.station}>
         <p>
           <strong>{station.Name}</strong>
+          {pushSupported() && (
+            <button className={css.notify} onClick={() => this.toggleSaved(station)}>
+              {this.state.saved[station.ID] ? "Stop notifying" : "Notify"}
+            </button>
+          )}
         </p>
         {this.renderArrival("Uptown / Manhattan", schedules.N)}
         {this.renderArrival("Downtown / Brooklyn", schedules.S)}
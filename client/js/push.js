@@ -0,0 +1,64 @@
+import rpc from "./rpc";
+
+const { pushKey } = window._ENVIRONMENT_ || {};
+
+// pushSupported reports whether the browser and the server support Web Push.
+export const pushSupported = () =>
+  !!pushKey && "serviceWorker" in navigator && "PushManager" in window;
+
+// savedStations returns the stations saved for notifications, by ID, with
+// the routes whose service status is notified.
+export const savedStations = () =>
+  JSON.parse(localStorage.getItem("pushStations") || "{}");
+
+// saveStation saves or, when routes is null, forgets a station, then
+// subscribes to the next trains of the saved stations and the status of
+// their routes.
+export const saveStation = (id, routes) => {
+  const saved = savedStations();
+  if (routes) {
+    saved[id] = routes;
+  } else {
+    delete saved[id];
+  }
+  return subscription().then(subscription => {
+    const stations = Object.keys(saved);
+    const allRoutes = [].concat(...stations.map(v => saved[v]));
+    return subscribePush(subscription, stations, allRoutes).then(() => {
+      localStorage.setItem("pushStations", JSON.stringify(saved));
+      return saved;
+    });
+  });
+};
+
+const subscription = () =>
+  navigator.serviceWorker.register("/static/sw.js").then(registration =>
+    registration.pushManager.getSubscription().then(
+      existing =>
+        existing ||
+        registration.pushManager.subscribe({
+          userVisibleOnly: true,
+          applicationServerKey: decodeKey(pushKey)
+        })
+    )
+  );
+
+const subscribePush = (subscription, stations, routes) =>
+  new Promise((resolve, reject) => {
+    rpc.request(
+      "SubscribePush",
+      { Subscription: subscription.toJSON(), Stations: stations, Routes: routes },
+      (err, error, result) => {
+        if (err || error) reject(err || error);
+        else resolve(result);
+      }
+    );
+  });
+
+// decodeKey decodes the unpadded base64url key the server publishes.
+const decodeKey = key => {
+  const base64 = (key + "=".repeat((4 - (key.length % 4)) % 4))
+    .replace(/-/g, "+")
+    .replace(/_/g, "/");
+  return Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+};
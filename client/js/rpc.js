@@ -40,5 +40,48 @@ export const GetClosestStations = coordinates => {
   });
 };
 
+// StreamClosestStations calls onStation with each of the closest stations,
+// then again whenever its arrivals change. The server sends each station
+// once, then only how its arrivals changed, which is applied here to the
+// station last seen. It returns the EventSource so the caller can close it.
+export const StreamClosestStations = (coordinates, onStation) => {
+  if (isEmptyObject(coordinates)) {
+    throw new Error("coordinates cannot be empty");
+  }
+  const { Lat, Lon } = coordinates;
+  const stations = {};
+  const source = new EventSource(`/stream?lat=${Lat}&lon=${Lon}&n=5`);
+  source.addEventListener("station", event => {
+    const station = JSON.parse(event.data);
+    stations[station.ID] = station;
+    onStation(station);
+  });
+  source.addEventListener("arrivals", event => {
+    const diff = JSON.parse(event.data);
+    const station = stations[diff.ID];
+    if (!station) return;
+    const arrivals = { ...station.Arrivals };
+    for (const [direction, tripIDs] of Object.entries(diff.Removed || {})) {
+      arrivals[direction] = (arrivals[direction] || []).filter(
+        a => !tripIDs.includes(a.TripID)
+      );
+    }
+    for (const changes of [diff.Changed || {}, diff.Added || {}]) {
+      for (const [direction, updated] of Object.entries(changes)) {
+        const tripIDs = updated.map(a => a.TripID);
+        arrivals[direction] = (arrivals[direction] || [])
+          .filter(a => !tripIDs.includes(a.TripID))
+          .concat(updated);
+      }
+    }
+    for (const direction of Object.keys(arrivals)) {
+      arrivals[direction].sort((a, b) => new Date(a.Time) - new Date(b.Time));
+    }
+    stations[diff.ID] = { ...station, Arrivals: arrivals, Updated: diff.Updated || station.Updated };
+    onStation(stations[diff.ID]);
+  });
+  return source;
+};
+
 const isEmptyObject = obj =>
   !!obj && Object.keys(obj).length === 0 && obj.constructor === Object;
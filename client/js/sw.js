@@ -0,0 +1,24 @@
+// Service worker showing the Web Push notifications of saved stations and
+// routes. It is copied as is, so it must not import modules.
+
+self.addEventListener("push", event => {
+  if (!event.data) return;
+  const { Title, Body, Tag } = event.data.json();
+  event.waitUntil(
+    self.registration.showNotification(Title, {
+      body: Body,
+      tag: Tag,
+      renotify: true
+    })
+  );
+});
+
+self.addEventListener("notificationclick", event => {
+  event.notification.close();
+  event.waitUntil(
+    clients.matchAll({ type: "window" }).then(windows => {
+      if (windows.length) return windows[0].focus();
+      return clients.openWindow("/");
+    })
+  );
+});
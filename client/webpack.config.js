@@ -35,6 +35,10 @@ module.exports = {
       {
         from: "./styles/main.css",
         to: "./main.css"
+      },
+      {
+        from: "./js/sw.js",
+        to: "./sw.js"
       }
     ])
   ]
@@ -0,0 +1,71 @@
+// Command mtasim serves simulated MTA feeds and service status, derived
+// from the static GTFS, for testing mtapi without the MTA. Trains progress
+// along each line, and a scenario may delay or suspend routes, make feeds
+// fail or malformed, and make trips vanish:
+//
+//	$ mtasim -port=9091 -delays=A=5 -suspended=L -malformed=16
+//	$ mtapi -feed-url=http://localhost:9091/mta_esi.php \
+//		-service-status-url=http://localhost:9091/status/serviceStatus.txt ...
+//
+// The scenario is served at /scenario and may be replaced with PUT.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+func main() {
+	var (
+		path      = flag.String("gtfs-path", "mta/testdata/gtfs", "gtfs directory")
+		port      = flag.Int("port", 9091, "port for server")
+		seed      = flag.Int64("seed", 1, "seed varying how late each trip runs")
+		headway   = flag.Duration("headway", 4*time.Minute, "time between trains of a route in each direction")
+		spacing   = flag.Duration("stop-spacing", 90*time.Second, "time between stations")
+		interval  = flag.Duration("interval", 30*time.Second, "time between publications of the feeds")
+		delays    = flag.String("delays", "", "comma-separated route=minutes pairs of routes running late")
+		suspended = flag.String("suspended", "", "comma-separated routes running no trains")
+		missing   = flag.String("missing", "", "comma-separated feeds failing with 503")
+		malformed = flag.String("malformed", "", "comma-separated feeds served truncated")
+		vanish    = flag.Float64("vanish", 0, "fraction of trips vanishing partway along their route")
+	)
+	flag.Parse()
+
+	if *headway <= 0 || *spacing <= 0 || *interval <= 0 {
+		log.Fatal("headway, stop spacing and interval must be positive")
+	}
+	lines, err := mtatest.ReadLines(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scenario := &Scenario{Suspended: splitList(*suspended), Vanish: *vanish}
+	if scenario.Delays, err = parseDelays(*delays); err != nil {
+		log.Fatal(err)
+	}
+	if scenario.Missing, err = parseFeedIDs(*missing); err != nil {
+		log.Fatal(err)
+	}
+	if scenario.Malformed, err = parseFeedIDs(*malformed); err != nil {
+		log.Fatal(err)
+	}
+	sim, err := newSimulator(lines, *seed, *headway, *spacing, *interval)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := sim.SetScenario(scenario); err != nil {
+		log.Fatal(err)
+	}
+
+	routes := make([]string, 0, len(lines))
+	for _, line := range lines {
+		routes = append(routes, line.RouteID)
+	}
+	log.Printf("simulating %s on :%d", strings.Join(routes, ","), *port)
+	log.Fatal(http.ListenAndServe(":"+strconv.Itoa(*port), sim))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+	"github.com/pkg/errors"
+)
+
+// Scenario describes the disruptions simulated.
+type Scenario struct {
+	// Delays are the minutes the trains of each route run late.
+	Delays map[string]int
+	// Suspended routes run no trains.
+	Suspended []string
+	// Missing feeds fail with 503 Service Unavailable.
+	Missing []int
+	// Malformed feeds are served truncated, as the MTA often serves them.
+	Malformed []int
+	// Vanish is the fraction of trips that disappear from their feed
+	// partway along their route.
+	Vanish float64
+}
+
+// validate returns an error if the scenario names routes or feeds not
+// simulated.
+func (s *Scenario) validate(lines []*mtatest.Line) error {
+	routes := make(map[string]bool)
+	feeds := make(map[int]bool)
+	for _, line := range lines {
+		routes[line.RouteID] = true
+		feeds[line.FeedID] = true
+	}
+	for routeID, minutes := range s.Delays {
+		if !routes[routeID] {
+			return errors.Errorf("unknown route %q", routeID)
+		}
+		if minutes < 0 {
+			return errors.Errorf("negative delay for route %q", routeID)
+		}
+	}
+	for _, routeID := range s.Suspended {
+		if !routes[routeID] {
+			return errors.Errorf("unknown route %q", routeID)
+		}
+	}
+	for _, feedIDs := range [][]int{s.Missing, s.Malformed} {
+		for _, feedID := range feedIDs {
+			if !feeds[feedID] {
+				return errors.Errorf("unknown feed %d", feedID)
+			}
+		}
+	}
+	if s.Vanish < 0 || s.Vanish > 1 {
+		return errors.Errorf("vanish %v is not a fraction", s.Vanish)
+	}
+	return nil
+}
+
+func (s *Scenario) delay(routeID string) int {
+	return s.Delays[routeID]
+}
+
+func (s *Scenario) suspended(routeID string) bool {
+	return containsString(s.Suspended, routeID)
+}
+
+func (s *Scenario) missing(feedID int) bool {
+	return containsInt(s.Missing, feedID)
+}
+
+func (s *Scenario) malformed(feedID int) bool {
+	return containsInt(s.Malformed, feedID)
+}
+
+// parseDelays parses comma-separated route=minutes pairs.
+func parseDelays(v string) (map[string]int, error) {
+	delays := make(map[string]int)
+	for _, pair := range splitList(v) {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid delay %q", pair)
+		}
+		minutes, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return nil, errors.Errorf("invalid delay %q", pair)
+		}
+		delays[kv[0]] = minutes
+	}
+	return delays, nil
+}
+
+// parseFeedIDs parses comma-separated feed IDs.
+func parseFeedIDs(v string) ([]int, error) {
+	var feedIDs []int
+	for _, s := range splitList(v) {
+		feedID, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.Errorf("invalid feed %q", s)
+		}
+		feedIDs = append(feedIDs, feedID)
+	}
+	return feedIDs, nil
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(s []int, v int) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
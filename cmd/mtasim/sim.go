@@ -0,0 +1,355 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+// lookahead is how long before departing a trip appears in its feed.
+const lookahead = 10 * time.Minute
+
+// maxJitter bounds how late a trip runs without a scenario delay.
+const maxJitter = 90 * time.Second
+
+// serviceStatusLines are the lines of the MTA's service status, which
+// groups routes.
+var serviceStatusLines = []string{"123", "456", "7", "ACE", "BDFM", "G", "JZ", "L", "NQR", "S", "SIR"}
+
+// simulator simulates the MTA feeds: trains of each line depart both ends
+// every headway and reach a station every stop spacing. What is served is
+// a function of the time, published every interval, the seed and the
+// scenario.
+type simulator struct {
+	lines    []*mtatest.Line
+	seed     int64
+	headway  time.Duration
+	spacing  time.Duration
+	interval time.Duration
+	loc      *time.Location
+	now      func() time.Time
+
+	mtx      sync.Mutex
+	scenario *Scenario
+	// generation counts the scenarios, so that a change of scenario is
+	// not mistaken for an unchanged feed.
+	generation int
+}
+
+func newSimulator(lines []*mtatest.Line, seed int64, headway, spacing, interval time.Duration) (*simulator, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, err
+	}
+	return &simulator{
+		lines:    lines,
+		seed:     seed,
+		headway:  headway,
+		spacing:  spacing,
+		interval: interval,
+		loc:      loc,
+		now:      time.Now,
+		scenario: &Scenario{},
+	}, nil
+}
+
+// Scenario returns the scenario simulated.
+func (s *simulator) Scenario() (*Scenario, int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.scenario, s.generation
+}
+
+// SetScenario replaces the scenario simulated.
+func (s *simulator) SetScenario(scenario *Scenario) error {
+	if err := scenario.validate(s.lines); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.scenario = scenario
+	s.generation++
+	return nil
+}
+
+// published returns when the feeds served at now were published.
+func (s *simulator) published(now time.Time) time.Time {
+	return now.Truncate(s.interval)
+}
+
+// Feed returns the message of a feed published at the given time.
+func (s *simulator) Feed(feedID int, at time.Time, scenario *Scenario) *gtfs.FeedMessage {
+	feed := &gtfs.FeedMessage{Header: &gtfs.FeedHeader{
+		GtfsRealtimeVersion: proto.String("1.0"),
+		Incrementality:      gtfs.FeedHeader_FULL_DATASET.Enum(),
+		Timestamp:           proto.Uint64(uint64(at.Unix())),
+	}}
+	for _, line := range s.lines {
+		if line.FeedID != feedID || scenario.suspended(line.RouteID) {
+			continue
+		}
+		for _, direction := range []string{"N", "S"} {
+			stops := line.Stops
+			if direction == "N" {
+				stops = reversed(stops)
+			}
+			s.trips(feed, line.RouteID, direction, stops, at, scenario)
+		}
+	}
+	return feed
+}
+
+// trips adds the trips of a route in a direction running at the given
+// time, or about to, to a feed.
+func (s *simulator) trips(feed *gtfs.FeedMessage, routeID, direction string, stops []string, at time.Time, scenario *Scenario) {
+	delay := time.Duration(scenario.delay(routeID)) * time.Minute
+	run := time.Duration(len(stops)) * s.spacing
+	earliest := at.Add(-run - delay - maxJitter)
+	for depart := at.Add(lookahead).Truncate(s.headway); depart.After(earliest); depart = depart.Add(-s.headway) {
+		h := s.hash(routeID, direction, depart)
+		late := delay + time.Duration(h%uint64(maxJitter/time.Second+1))*time.Second
+		// The trip has reached the stops it is no longer predicted to
+		// arrive at.
+		next := 0
+		for next < len(stops) && !s.arrival(depart, late, next).After(at) {
+			next++
+		}
+		if next == len(stops) {
+			continue
+		}
+		if float64(h>>32%1000) < scenario.Vanish*1000 && next >= int(h>>16%uint64(len(stops))) {
+			continue
+		}
+
+		local := depart.In(s.loc)
+		midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.loc)
+		origin := int(depart.Sub(midnight) / time.Minute)
+		trip := &gtfs.TripDescriptor{
+			TripId:    proto.String(fmt.Sprintf("%06d_%s..%s", origin*100, routeID, direction)),
+			StartDate: proto.String(local.Format("20060102")),
+			RouteId:   proto.String(routeID),
+		}
+		var updates []*gtfs.TripUpdate_StopTimeUpdate
+		for i := next; i < len(stops); i++ {
+			t := s.arrival(depart, late, i).Unix()
+			updates = append(updates, &gtfs.TripUpdate_StopTimeUpdate{
+				StopId:    proto.String(stops[i] + direction),
+				Arrival:   &gtfs.TripUpdate_StopTimeEvent{Time: proto.Int64(t)},
+				Departure: &gtfs.TripUpdate_StopTimeEvent{Time: proto.Int64(t)},
+			})
+		}
+		status := gtfs.VehiclePosition_IN_TRANSIT_TO
+		if next == 0 {
+			status = gtfs.VehiclePosition_STOPPED_AT
+		}
+		id := strconv.Itoa(len(feed.Entity) + 1)
+		feed.Entity = append(feed.Entity, &gtfs.FeedEntity{
+			Id:         proto.String(id),
+			TripUpdate: &gtfs.TripUpdate{Trip: trip, StopTimeUpdate: updates},
+		}, &gtfs.FeedEntity{
+			Id: proto.String(id + "v"),
+			Vehicle: &gtfs.VehiclePosition{
+				Trip:          trip,
+				StopId:        proto.String(stops[next] + direction),
+				CurrentStatus: status.Enum(),
+				Timestamp:     proto.Uint64(uint64(at.Unix())),
+			},
+		})
+	}
+}
+
+// arrival returns when a trip scheduled to depart its first stop at the
+// given time, and running late, arrives at its stop i.
+func (s *simulator) arrival(depart time.Time, late time.Duration, i int) time.Time {
+	return depart.Add(late + time.Duration(i)*s.spacing)
+}
+
+// hash returns a number derived from the seed and a trip, so that a trip
+// runs the same way every time it is served.
+func (s *simulator) hash(routeID, direction string, depart time.Time) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%s/%d", s.seed, routeID, direction, depart.Unix())
+	return h.Sum64()
+}
+
+func reversed(s []string) []string {
+	result := make([]string, len(s))
+	for i, v := range s {
+		result[len(s)-1-i] = v
+	}
+	return result
+}
+
+// serviceStatus is the MTA's service status document.
+type serviceStatus struct {
+	XMLName      xml.Name            `xml:"service"`
+	ResponseCode int                 `xml:"responsecode"`
+	Timestamp    string              `xml:"timestamp"`
+	Lines        []serviceStatusLine `xml:"subway>line"`
+}
+
+type serviceStatusLine struct {
+	Name   string `xml:"name"`
+	Status string `xml:"status"`
+	Text   string `xml:"text"`
+	Date   string `xml:"Date"`
+	Time   string `xml:"Time"`
+}
+
+// ServiceStatus returns the service status published at the given time,
+// with the lines of suspended routes suspended and of delayed routes
+// delayed.
+func (s *simulator) ServiceStatus(at time.Time, scenario *Scenario) ([]byte, error) {
+	local := at.In(s.loc)
+	doc := &serviceStatus{Timestamp: local.Format("1/2/2006 3:04:05 PM")}
+	for _, name := range serviceStatusLines {
+		line := serviceStatusLine{Name: name, Status: "GOOD SERVICE"}
+		var suspended, delayed []string
+		for _, routeID := range s.routes() {
+			if statusLine(routeID) != name {
+				continue
+			}
+			switch {
+			case scenario.suspended(routeID):
+				suspended = append(suspended, "["+routeID+"]")
+			case scenario.delay(routeID) > 0:
+				delayed = append(delayed, "["+routeID+"]")
+			}
+		}
+		switch {
+		case len(suspended) > 0:
+			line.Status = "SUSPENDED"
+			line.Text = fmt.Sprintf(`<span class="TitleDelay">Suspended</span><br/><P>%s trains are suspended in both directions.</P>`, strings.Join(suspended, ", "))
+		case len(delayed) > 0:
+			line.Status = "DELAYS"
+			line.Text = fmt.Sprintf(`<span class="TitleDelay">Delays</span><br/><P>%s trains are running with delays.</P>`, strings.Join(delayed, ", "))
+		}
+		if line.Text != "" {
+			line.Date = local.Format("01/02/2006")
+			line.Time = local.Format(" 3:04PM")
+		}
+		doc.Lines = append(doc.Lines, line)
+	}
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(`<?xml version="1.0" encoding="utf-8"?>`+"\n"), b...), nil
+}
+
+// routes returns the routes simulated, in order.
+func (s *simulator) routes() []string {
+	var routes []string
+	for _, line := range s.lines {
+		routes = append(routes, line.RouteID)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// statusLine returns the line of the service status a route belongs to.
+func statusLine(routeID string) string {
+	switch routeID {
+	case "GS", "FS", "H":
+		return "S"
+	case "SI":
+		return "SIR"
+	}
+	for _, name := range serviceStatusLines {
+		if name != "S" && name != "SIR" && strings.Contains(name, routeID) {
+			return name
+		}
+	}
+	return ""
+}
+
+// ServeHTTP serves the feeds and service status at the paths the MTA does,
+// and the scenario at /scenario, which may be replaced with PUT.
+func (s *simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case mtatest.FeedPath:
+		s.serveFeed(w, r)
+	case mtatest.ServiceStatusPath:
+		s.serveServiceStatus(w, r)
+	case "/scenario":
+		s.serveScenario(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *simulator) serveFeed(w http.ResponseWriter, r *http.Request) {
+	feedID, err := strconv.Atoi(r.URL.Query().Get("feed_id"))
+	if err != nil {
+		http.Error(w, "invalid feed_id", http.StatusBadRequest)
+		return
+	}
+	scenario, generation := s.Scenario()
+	if scenario.missing(feedID) {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	at := s.published(s.now())
+	etag := fmt.Sprintf(`"%d-%d-%d"`, feedID, generation, at.Unix())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", at.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	b, err := proto.Marshal(s.Feed(feedID, at, scenario))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if scenario.malformed(feedID) {
+		b = b[:len(b)/2]
+	}
+	w.Header().Set("Content-Type", "application/x-google-protobuf")
+	w.Write(b)
+}
+
+func (s *simulator) serveServiceStatus(w http.ResponseWriter, r *http.Request) {
+	scenario, _ := s.Scenario()
+	b, err := s.ServiceStatus(s.published(s.now()), scenario)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(b)
+}
+
+func (s *simulator) serveScenario(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "PUT":
+		var scenario Scenario
+		if err := json.NewDecoder(r.Body).Decode(&scenario); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := s.SetScenario(&scenario); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	scenario, _ := s.Scenario()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scenario)
+}
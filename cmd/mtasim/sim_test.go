@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+var testTime = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func simulate(t *testing.T) *simulator {
+	lines, err := mtatest.ReadLines("../../mta/testdata/gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim, err := newSimulator(lines, 1, 4*time.Minute, 90*time.Second, 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.now = func() time.Time { return testTime }
+	return sim
+}
+
+// tripUpdates returns the trip updates of a feed by trip ID.
+func tripUpdates(feed *gtfs.FeedMessage) map[string]*gtfs.TripUpdate {
+	result := make(map[string]*gtfs.TripUpdate)
+	for _, e := range feed.Entity {
+		if u := e.TripUpdate; u != nil {
+			result[u.GetTrip().GetTripId()] = u
+		}
+	}
+	return result
+}
+
+func TestFeed(t *testing.T) {
+	sim := simulate(t)
+	feed := sim.Feed(2, testTime, &Scenario{})
+	if !proto.Equal(feed, sim.Feed(2, testTime, &Scenario{})) {
+		t.Error("feed is not deterministic")
+	}
+	trips := tripUpdates(feed)
+	if len(trips) == 0 {
+		t.Fatal("no trips")
+	}
+	for id, u := range trips {
+		if got, want := u.GetTrip().GetRouteId(), "L"; got != want {
+			t.Errorf("%s: got %v, want %v", id, got, want)
+		}
+		for _, s := range u.StopTimeUpdate {
+			if !strings.HasPrefix(s.GetStopId(), "L") {
+				t.Errorf("%s: got stop %v", id, s.GetStopId())
+			}
+		}
+	}
+
+	// A stop spacing later, trips have moved on a station.
+	later := tripUpdates(sim.Feed(2, testTime.Add(90*time.Second), &Scenario{}))
+	var moved int
+	for id, u := range trips {
+		v, ok := later[id]
+		if !ok {
+			continue
+		}
+		if len(u.StopTimeUpdate) == len(v.StopTimeUpdate)+1 {
+			moved++
+		}
+		if got, want := v.StopTimeUpdate[0].GetArrival().GetTime(), u.StopTimeUpdate[len(u.StopTimeUpdate)-len(v.StopTimeUpdate)].GetArrival().GetTime(); got != want {
+			t.Errorf("%s: got %v, want %v", id, got, want)
+		}
+	}
+	if moved == 0 {
+		t.Error("no trips moved")
+	}
+}
+
+func TestScenario(t *testing.T) {
+	sim := simulate(t)
+	base := tripUpdates(sim.Feed(2, testTime, &Scenario{}))
+
+	var tests = []struct {
+		name     string
+		scenario *Scenario
+		check    func(map[string]*gtfs.TripUpdate) bool
+	}{
+		{"suspended", &Scenario{Suspended: []string{"L"}}, func(trips map[string]*gtfs.TripUpdate) bool {
+			return len(trips) == 0
+		}},
+		{"vanish", &Scenario{Vanish: 1}, func(trips map[string]*gtfs.TripUpdate) bool {
+			return len(trips) < len(base)
+		}},
+		{"delays", &Scenario{Delays: map[string]int{"L": 5}}, func(trips map[string]*gtfs.TripUpdate) bool {
+			for id, u := range trips {
+				v, ok := base[id]
+				if !ok {
+					continue
+				}
+				last := func(u *gtfs.TripUpdate) int64 {
+					return u.StopTimeUpdate[len(u.StopTimeUpdate)-1].GetArrival().GetTime()
+				}
+				if last(u)-last(v) != 5*60 {
+					return false
+				}
+			}
+			return true
+		}},
+	}
+	for _, tt := range tests {
+		if !tt.check(tripUpdates(sim.Feed(2, testTime, tt.scenario))) {
+			t.Errorf("%s: unexpected trips", tt.name)
+		}
+	}
+}
+
+func TestServiceStatus(t *testing.T) {
+	sim := simulate(t)
+	b, err := sim.ServiceStatus(testTime, &Scenario{Suspended: []string{"L"}, Delays: map[string]int{"A": 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc serviceStatus
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doc.Timestamp, "6/1/2018 8:00:00 AM"; got != want {
+		t.Errorf("timestamp: got %v, want %v", got, want)
+	}
+	status := make(map[string]string)
+	for _, line := range doc.Lines {
+		status[line.Name] = line.Status
+	}
+	want := map[string]string{
+		"123": "GOOD SERVICE", "456": "GOOD SERVICE", "7": "GOOD SERVICE", "ACE": "DELAYS",
+		"BDFM": "GOOD SERVICE", "G": "GOOD SERVICE", "JZ": "GOOD SERVICE", "L": "SUSPENDED",
+		"NQR": "GOOD SERVICE", "S": "GOOD SERVICE", "SIR": "GOOD SERVICE",
+	}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("got %v, want %v", status, want)
+	}
+}
+
+func TestServeFeed(t *testing.T) {
+	sim := simulate(t)
+	if err := sim.SetScenario(&Scenario{Missing: []int{21}, Malformed: []int{16}}); err != nil {
+		t.Fatal(err)
+	}
+	get := func(feedID, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", mtatest.FeedPath+"?key=&feed_id="+feedID, nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		sim.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("2", "")
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if err := proto.Unmarshal(w.Body.Bytes(), &gtfs.FeedMessage{}); err != nil {
+		t.Error(err)
+	}
+	if got, want := get("2", w.Header().Get("ETag")).Code, http.StatusNotModified; got != want {
+		t.Errorf("conditional: got %v, want %v", got, want)
+	}
+	if got, want := get("21", "").Code, http.StatusServiceUnavailable; got != want {
+		t.Errorf("missing: got %v, want %v", got, want)
+	}
+	if err := proto.Unmarshal(get("16", "").Body.Bytes(), &gtfs.FeedMessage{}); err == nil {
+		t.Error("malformed: got nil, want error")
+	}
+	if err := sim.SetScenario(&Scenario{Suspended: []string{"X"}}); err == nil {
+		t.Error("unknown route: got nil, want error")
+	}
+}
@@ -1,25 +1,66 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/dcowgill/envflag"
 	raven "github.com/getsentry/raven-go"
 	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/pkg/webpush"
 	"github.com/jeffreylo/mtapi/server"
+	"github.com/pkg/errors"
 )
 
+// shutdownTimeout is how long requests in flight are given to complete
+// after SIGTERM; Heroku kills the process 30 seconds after sending it.
+const shutdownTimeout = 20 * time.Second
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		if err := report(os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "vapid-keys" {
+		if err := vapidKeys(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var (
 		apiKey      = flag.String("api-key", "", "API key from http://datamine.mta.info/")
+		apiKeys     = flag.String("api-keys", "", "comma-separated consumer=key pairs of issued API keys")
+		keyRate     = flag.Float64("key-rate", 10, "requests per second allowed per API key, or 0 for no limit")
+		anonRate    = flag.Float64("anonymous-rate", 1, "requests per second allowed per IP address without an API key, or 0 for no limit")
+		trustProxy  = flag.Bool("trust-proxy", false, "take client addresses from the last X-Forwarded-For entry, as appended by the Heroku router")
+		staleAfter  = flag.Duration("stale-after", 2*time.Minute, "how long a feed may not update a prediction before the arrival is stale")
+		maxFeedAge  = flag.Duration("max-feed-age", 5*time.Minute, "how long a feed may go without a successful fetch before /readyz reports it stale")
 		ensureSSL   = flag.Bool("ensure-ssl", true, "always redirect to https://")
+		feedURL     = flag.String("feed-url", "", "URL to fetch the feeds from instead of the MTA's, e.g., mtasim's")
+		statusURL   = flag.String("service-status-url", "", "URL to fetch the service status from instead of the MTA's")
 		environment = flag.String("environment", "", "environment")
 		path        = flag.String("gtfs-path", "", "gtfs directory")
 		port        = flag.Int("port", 3000, "port for server")
+		recordPath  = flag.String("record-path", "", "database to record predictions, arrivals and status changes to")
+		retention   = flag.Duration("record-retention", 30*24*time.Hour, "how long recorded predictions and arrivals are kept")
 		sentryDSN   = flag.String("sentry-dsn", "", "sentry dsn")
 		release     = flag.String("release", "", "release identifier")
 		staticPath  = flag.String("static-path", "", "path to static directory")
+		vapidKey    = flag.String("vapid-private-key", "", "VAPID private key from mtapi vapid-keys, enabling Web Push")
+		vapidSub    = flag.String("vapid-subject", "", "mailto: or https: URL push services may contact the operator at")
+		metricsTok  = flag.String("metrics-token", "", "bearer token required to read /metrics and /debug/vars, which are public without one")
 	)
 
 	flag.Parse()
@@ -41,15 +82,47 @@ func main() {
 		raven.SetEnvironment(*environment)
 		raven.SetRelease(*release)
 	}
-	client, err := mta.NewClient(&mta.ClientConfig{
+	cfg := &mta.ClientConfig{
 		APIKey:            *apiKey,
 		StopsFilePath:     *path + "/stops.txt",
 		TransfersFilePath: *path + "/transfers.txt",
-	})
+		RoutesFilePath:    *path + "/routes.txt",
+		RecordPath:        *recordPath,
+		RecordRetention:   *retention,
+		StaleAfter:        *staleAfter,
+		FeedURL:           *feedURL,
+		ServiceStatusURL:  *statusURL,
+	}
+	// stop_times.txt is large and not always distributed with the feed.
+	if _, err := os.Stat(*path + "/stop_times.txt"); err == nil {
+		cfg.TripsFilePath = *path + "/trips.txt"
+		cfg.CalendarFilePath = *path + "/calendar.txt"
+		cfg.CalendarDatesFilePath = *path + "/calendar_dates.txt"
+		cfg.StopTimesFilePath = *path + "/stop_times.txt"
+	}
+	keys, err := parseAPIKeys(*apiKeys)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var vapid *webpush.VAPID
+	if *vapidKey != "" {
+		key, err := webpush.DecodePrivateKey(*vapidKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *vapidSub == "" {
+			log.Fatal("missing VAPID subject")
+		}
+		vapid = &webpush.VAPID{Key: key, Subject: *vapidSub}
+	}
+	client, err := mta.NewClient(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
-	go client.Work()
+	// The feeds are polled until SIGTERM.
+	work, stop := context.WithCancel(context.Background())
+	defer stop()
+	go client.Work(work)
 
 	server := server.New(&server.Params{
 		Client:      client,
@@ -58,6 +131,68 @@ func main() {
 		Port:        *port,
 		Release:     *release,
 		StaticPath:  *staticPath,
+
+		APIKeys:       keys,
+		KeyRate:       *keyRate,
+		AnonymousRate: *anonRate,
+		TrustProxy:    *trustProxy,
+		MaxFeedAge:    *maxFeedAge,
+		VAPID:         vapid,
+		MetricsToken:  *metricsTok,
 	})
-	log.Fatal(server.Serve())
+
+	errc := make(chan error, 1)
+	go func() { errc <- server.Serve() }()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
+	select {
+	case err := <-errc:
+		client.Close()
+		log.Fatal(err)
+	case s := <-sig:
+		log.Printf("received %v, shutting down", s)
+	}
+	stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Print(err)
+	}
+	if err := <-errc; err != http.ErrServerClosed {
+		log.Print(err)
+	}
+	// Close waits for Work to return and writes any pending records.
+	if err := client.Close(); err != nil {
+		log.Print(err)
+	}
+}
+
+// parseAPIKeys parses comma-separated consumer=key pairs into a map of keys
+// to consumers.
+func parseAPIKeys(s string) (map[string]string, error) {
+	keys := make(map[string]string)
+	if s == "" {
+		return keys, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, errors.Errorf("invalid API key %q", pair)
+		}
+		keys[kv[1]] = kv[0]
+	}
+	return keys, nil
+}
+
+// vapidKeys prints a new VAPID key pair in the form of environment
+// variables.
+func vapidKeys(w io.Writer) error {
+	key, err := webpush.GenerateKey()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "VAPID_PRIVATE_KEY=%s\nVAPID_PUBLIC_KEY=%s\n",
+		webpush.EncodePrivateKey(key), webpush.EncodePublicKey(&key.PublicKey))
+	return err
 }
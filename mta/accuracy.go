@@ -0,0 +1,197 @@
+package mta
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+	"github.com/pkg/errors"
+)
+
+// LookaheadBucket groups predictions by how far ahead of the arrival they
+// were made.
+type LookaheadBucket struct {
+	Name     string
+	Min, Max time.Duration
+}
+
+// LookaheadBuckets are the buckets accuracy is reported in; the last one is
+// unbounded.
+var LookaheadBuckets = []LookaheadBucket{
+	{"0-2", 0, 2 * time.Minute},
+	{"2-5", 2 * time.Minute, 5 * time.Minute},
+	{"5-10", 5 * time.Minute, 10 * time.Minute},
+	{"10+", 10 * time.Minute, 0},
+}
+
+func lookaheadBucket(d time.Duration) int {
+	for i, b := range LookaheadBuckets {
+		if d >= b.Min && (b.Max == 0 || d < b.Max) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Accuracy is the distribution of prediction error, i.e., the actual minus
+// the predicted arrival time, for a route and lookahead bucket. Positive
+// errors are trains arriving later than predicted.
+//
+// The feeds do not report arrivals, so the actual time is inferred as the
+// last prediction for the stop, capped at when the trip was found past it.
+// The last predictions are made shortly before the arrival, so the error
+// is biased toward zero, most at short lookaheads.
+type Accuracy struct {
+	RouteID string
+	Bucket  string
+	Count   int
+	Mean    time.Duration
+	P10     time.Duration
+	P50     time.Duration
+	P90     time.Duration
+	// OnTime is the fraction of predictions within a minute of the arrival.
+	OnTime float64
+}
+
+var errNotRecording = errors.New("arrivals are not being recorded")
+
+// PredictionAccuracy summarizes the error of the predictions recorded for
+// arrivals since the given time, optionally restricted to some routes.
+func (s *Store) PredictionAccuracy(routes []string, since time.Time) ([]*Accuracy, error) {
+	type key struct {
+		routeID string
+		bucket  int
+	}
+	errs := make(map[key][]time.Duration)
+	err := s.Arrivals(since, func(a *ObservedArrival, predictions []*Prediction) error {
+		if a.Time.Before(since) {
+			return nil
+		}
+		if len(routes) > 0 && !strings2.SliceContains(routes, a.RouteID) {
+			return nil
+		}
+		for _, p := range predictions {
+			lookahead := a.Time.Sub(p.Observed)
+			if lookahead < 0 {
+				continue
+			}
+			k := key{a.RouteID, lookaheadBucket(lookahead)}
+			errs[k] = append(errs[k], a.Time.Sub(p.Time))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Accuracy, 0, len(errs))
+	for k, v := range errs {
+		result = append(result, summarize(k.routeID, LookaheadBuckets[k.bucket].Name, v))
+	}
+	bucketIndex := make(map[string]int, len(LookaheadBuckets))
+	for i, b := range LookaheadBuckets {
+		bucketIndex[b.Name] = i
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RouteID != result[j].RouteID {
+			return result[i].RouteID < result[j].RouteID
+		}
+		return bucketIndex[result[i].Bucket] < bucketIndex[result[j].Bucket]
+	})
+	return result, nil
+}
+
+func summarize(routeID, bucket string, errs []time.Duration) *Accuracy {
+	sort.Slice(errs, func(i, j int) bool { return errs[i] < errs[j] })
+	var sum time.Duration
+	onTime := 0
+	for _, v := range errs {
+		sum += v
+		if v > -time.Minute && v < time.Minute {
+			onTime++
+		}
+	}
+	percentile := func(p int) time.Duration {
+		return errs[(len(errs)-1)*p/100]
+	}
+	return &Accuracy{
+		RouteID: routeID,
+		Bucket:  bucket,
+		Count:   len(errs),
+		Mean:    sum / time.Duration(len(errs)),
+		P10:     percentile(10),
+		P50:     percentile(50),
+		P90:     percentile(90),
+		OnTime:  float64(onTime) / float64(len(errs)),
+	}
+}
+
+const (
+	// accuracyTTL is how long a summary of accuracy is reused; since is
+	// rounded down to it.
+	accuracyTTL = time.Minute
+	// maxAccuracyEntries bounds the summaries cached.
+	maxAccuracyEntries = 64
+)
+
+type accuracyEntry struct {
+	accuracy []*Accuracy
+	at       time.Time
+}
+
+// accuracyCache reuses recent summaries of accuracy and summarizes one at a
+// time, as each reads the records since a time.
+type accuracyCache struct {
+	store   *Store
+	mtx     *sync.Mutex
+	entries map[string]*accuracyEntry
+}
+
+func newAccuracyCache(store *Store) *accuracyCache {
+	return &accuracyCache{
+		store:   store,
+		mtx:     &sync.Mutex{},
+		entries: make(map[string]*accuracyEntry),
+	}
+}
+
+func (c *accuracyCache) get(routes []string, since, now time.Time) ([]*Accuracy, error) {
+	since = since.Truncate(accuracyTTL)
+	sorted := append([]string(nil), routes...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, ",") + "@" + since.UTC().Format(time.RFC3339)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e, ok := c.entries[key]; ok && now.Sub(e.at) < accuracyTTL {
+		return e.accuracy, nil
+	}
+	accuracy, err := c.store.PredictionAccuracy(routes, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.entries) >= maxAccuracyEntries {
+		for k, e := range c.entries {
+			if now.Sub(e.at) >= accuracyTTL {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxAccuracyEntries {
+			c.entries = make(map[string]*accuracyEntry)
+		}
+	}
+	c.entries[key] = &accuracyEntry{accuracy, now}
+	return accuracy, nil
+}
+
+// GetPredictionAccuracy returns the accuracy of the predictions recorded by
+// the client. Since is rounded down to the minute, and the result may be up
+// to a minute old.
+func (c *Client) GetPredictionAccuracy(routes []string, since time.Time) ([]*Accuracy, error) {
+	if c.accuracy == nil {
+		return nil, errNotRecording
+	}
+	return c.accuracy.get(routes, since, time.Now().UTC())
+}
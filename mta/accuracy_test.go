@@ -0,0 +1,95 @@
+package mta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPredictionAccuracy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := OpenStore(filepath.Join(dir, "record.db"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	arrived := time.Now().UTC().Truncate(time.Second)
+	startDate := arrived.Format("20060102")
+	// predict returns a prediction made lookahead minutes before the
+	// arrival that was off by the given number of seconds.
+	predict := func(tripID, routeID string, lookahead, off int) *Prediction {
+		return &Prediction{
+			TripID:    tripID,
+			StartDate: startDate,
+			RouteID:   routeID,
+			StopID:    "R20N",
+			Time:      arrived.Add(-time.Duration(off) * time.Second),
+			Observed:  arrived.Add(-time.Duration(lookahead) * time.Minute),
+		}
+	}
+	arrival := func(tripID, routeID string) *ObservedArrival {
+		return &ObservedArrival{TripID: tripID, StartDate: startDate, RouteID: routeID, StopID: "R20N", Time: arrived}
+	}
+	err = store.Write([]*Prediction{
+		predict("a", "Q", 12, 240),
+		predict("a", "Q", 7, 120),
+		predict("a", "Q", 1, 0),
+		predict("b", "Q", 1, 60),
+		predict("c", "N", 3, -30),
+		predict("d", "N", 1, 0),
+	}, []*ObservedArrival{arrival("a", "Q"), arrival("b", "Q"), arrival("c", "N")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		routeID, bucket string
+		count           int
+		mean            time.Duration
+		onTime          float64
+	}{
+		{"N", "2-5", 1, -30 * time.Second, 1},
+		{"Q", "0-2", 2, 30 * time.Second, 0.5},
+		{"Q", "5-10", 1, 2 * time.Minute, 0},
+		{"Q", "10+", 1, 4 * time.Minute, 0},
+	}
+	accuracy, err := store.PredictionAccuracy(nil, arrived.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accuracy) != len(tests) {
+		t.Fatalf("accuracy got %v, want %v", len(accuracy), len(tests))
+	}
+	for i, tt := range tests {
+		v := accuracy[i]
+		if v.RouteID != tt.routeID || v.Bucket != tt.bucket || v.Count != tt.count || v.Mean != tt.mean || v.OnTime != tt.onTime {
+			t.Errorf("accuracy[%d] got %+v, want %+v", i, *v, tt)
+		}
+	}
+
+	accuracy, _ = store.PredictionAccuracy([]string{"N"}, arrived.Add(time.Minute))
+	if len(accuracy) != 0 {
+		t.Errorf("accuracy got %v, want none", len(accuracy))
+	}
+
+	// Summaries are reused for a minute.
+	cache := newAccuracyCache(store)
+	first, err := cache.get([]string{"N"}, arrived.Add(-time.Hour), arrived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Write([]*Prediction{predict("d", "N", 3, 0)}, []*ObservedArrival{arrival("d", "N")})
+	if got, _ := cache.get([]string{"N"}, arrived.Add(-time.Hour), arrived.Add(30*time.Second)); got[0].Count != first[0].Count {
+		t.Errorf("cached count got %v, want %v", got[0].Count, first[0].Count)
+	}
+	if got, _ := cache.get([]string{"N"}, arrived.Add(-time.Hour), arrived.Add(time.Minute)); len(got) != 2 {
+		t.Errorf("accuracy got %v, want %v", len(got), 2)
+	}
+}
@@ -0,0 +1,252 @@
+package mta
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+)
+
+const (
+	// ghostGrace is how far off a trip's next arrival may be when it
+	// leaves the feed without being considered a ghost.
+	ghostGrace = time.Minute
+	// stallAfter is how long a trip may predict the same next stop
+	// before it is considered stalled.
+	stallAfter = 10 * time.Minute
+	// minTrainSpacing is the gap under which consecutive trains on a
+	// route are considered bunched.
+	minTrainSpacing = 90 * time.Second
+	// anomalyRetention is how long detected anomalies are kept.
+	anomalyRetention = time.Hour
+)
+
+// AnomalyKind classifies an anomaly.
+type AnomalyKind string
+
+// Anomaly kinds.
+const (
+	// AnomalyGhost is a trip that left the feed before reaching its
+	// predicted stop.
+	AnomalyGhost AnomalyKind = "ghost"
+	// AnomalyStalled is a trip whose predictions do not advance.
+	AnomalyStalled AnomalyKind = "stalled"
+	// AnomalyBunching is a pair of consecutive trains under the minimum
+	// spacing.
+	AnomalyBunching AnomalyKind = "bunching"
+)
+
+// Anomaly is an irregularity observed in the realtime feeds.
+type Anomaly struct {
+	Kind      AnomalyKind
+	RouteID   string
+	TripIDs   []string
+	StationID StationID
+	Direction Direction
+	// Time is the predicted arrival at the station concerned.
+	Time     *time.Time
+	Detected *time.Time
+}
+
+// tripState is what was last observed of a trip.
+type tripState struct {
+	routeID   string
+	startDate string
+	feedID    int
+	stopID    string
+	arrival   time.Time
+	stopSince time.Time
+	stalled   bool
+}
+
+// tripObservation is a trip's next stop as reported by a feed.
+type tripObservation struct {
+	TripID, StartDate, RouteID, StopID string
+	Arrival                            time.Time
+}
+
+// tracker follows trips across refresh cycles.
+type tracker struct {
+	mtx       sync.Mutex
+	trips     map[string]*tripState
+	bunched   map[[2]string]time.Time
+	anomalies []*Anomaly
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		trips:   make(map[string]*tripState),
+		bunched: make(map[[2]string]time.Time),
+	}
+}
+
+func (t *tracker) record(a *Anomaly) {
+	t.anomalies = append(t.anomalies, a)
+	anomaliesDetected.Inc(string(a.Kind), a.RouteID)
+}
+
+// observe updates the trips of a feed, flagging ghost and stalled trips,
+// and returns the arrivals inferred from trips moving past their stops.
+func (t *tracker) observe(c *Client, feedID int, trips []*tripObservation, now time.Time) []*ObservedArrival {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	var arrivals []*ObservedArrival
+	seen := make(map[string]struct{}, len(trips))
+	for _, v := range trips {
+		seen[v.TripID] = struct{}{}
+		s, ok := t.trips[v.TripID]
+		if !ok || s.stopID != v.StopID {
+			if ok {
+				arrivals = append(arrivals, s.arrived(v.TripID, now))
+			}
+			t.trips[v.TripID] = &tripState{
+				routeID:   v.RouteID,
+				startDate: v.StartDate,
+				feedID:    feedID,
+				stopID:    v.StopID,
+				arrival:   v.Arrival,
+				stopSince: now,
+			}
+			continue
+		}
+		s.arrival = v.Arrival
+		if !s.stalled && now.Sub(s.stopSince) > stallAfter {
+			s.stalled = true
+			t.record(c.tripAnomaly(AnomalyStalled, v.TripID, s, now))
+		}
+	}
+
+	for id, s := range t.trips {
+		if s.feedID != feedID {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		if s.arrival.After(now.Add(ghostGrace)) {
+			t.record(c.tripAnomaly(AnomalyGhost, id, s, now))
+		} else {
+			arrivals = append(arrivals, s.arrived(id, now))
+		}
+		delete(t.trips, id)
+	}
+	t.expire(now)
+	return arrivals
+}
+
+// arrived infers the arrival at the trip's last predicted stop, which it
+// was found to have passed at the given time.
+func (s *tripState) arrived(tripID string, now time.Time) *ObservedArrival {
+	t := s.arrival
+	if t.After(now) {
+		t = now
+	}
+	return &ObservedArrival{
+		TripID:    tripID,
+		StartDate: s.startDate,
+		RouteID:   s.routeID,
+		StopID:    s.stopID,
+		Time:      t,
+	}
+}
+
+// bunching flags consecutive trains on a route closer than the minimum
+// spacing, once per pair of trips.
+func (t *tracker) bunching(c *Client, now time.Time) {
+	type pair struct {
+		a, b      *Arrival
+		stationID StationID
+		direction Direction
+	}
+	var pairs []pair
+	c.mtx.Lock()
+	for _, station := range c.stations {
+		for direction, arrivals := range station.Arrivals {
+			last := make(map[string]*Arrival)
+			for _, v := range arrivals {
+				if v.Time == nil {
+					continue
+				}
+				if prev, ok := last[v.RouteID]; ok && v.Time.Sub(*prev.Time) < minTrainSpacing {
+					pairs = append(pairs, pair{prev, v, station.ID, direction})
+				}
+				last[v.RouteID] = v
+			}
+		}
+	}
+	c.mtx.Unlock()
+
+	// Report each pair at the station it reaches first.
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].a.Time.Before(*pairs[j].a.Time) })
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, v := range pairs {
+		key := [2]string{v.a.TripID, v.b.TripID}
+		if _, ok := t.bunched[key]; ok {
+			continue
+		}
+		t.bunched[key] = now
+		detected := now
+		t.record(&Anomaly{
+			Kind:      AnomalyBunching,
+			RouteID:   v.a.RouteID,
+			TripIDs:   []string{v.a.TripID, v.b.TripID},
+			StationID: v.stationID,
+			Direction: v.direction,
+			Time:      v.a.Time,
+			Detected:  &detected,
+		})
+	}
+}
+
+func (t *tracker) expire(now time.Time) {
+	cutoff := now.Add(-anomalyRetention)
+	i := 0
+	for i < len(t.anomalies) && t.anomalies[i].Detected.Before(cutoff) {
+		i++
+	}
+	t.anomalies = t.anomalies[i:]
+	for k, v := range t.bunched {
+		if v.Before(cutoff) {
+			delete(t.bunched, k)
+		}
+	}
+}
+
+func (c *Client) tripAnomaly(kind AnomalyKind, tripID string, s *tripState, now time.Time) *Anomaly {
+	arrival := s.arrival
+	a := &Anomaly{
+		Kind:     kind,
+		RouteID:  s.routeID,
+		TripIDs:  []string{tripID},
+		Time:     &arrival,
+		Detected: &now,
+	}
+	if n := len(s.stopID); n > 1 {
+		if station, err := c.GetStationByStopID(s.stopID[:n-1]); err == nil {
+			a.StationID = station.ID
+		}
+		a.Direction = Direction(s.stopID[n-1:])
+	}
+	return a
+}
+
+// GetAnomalies returns the anomalies detected within the retention period,
+// most recent first, optionally restricted to the given routes.
+func (c *Client) GetAnomalies(routes []string) []*Anomaly {
+	c.tracker.mtx.Lock()
+	defer c.tracker.mtx.Unlock()
+
+	result := make([]*Anomaly, 0, len(c.tracker.anomalies))
+	for i := len(c.tracker.anomalies) - 1; i >= 0; i-- {
+		v := c.tracker.anomalies[i]
+		if len(routes) > 0 && !strings2.SliceContains(routes, v.RouteID) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
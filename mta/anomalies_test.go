@@ -0,0 +1,78 @@
+package mta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackerGhostAndStalled(t *testing.T) {
+	c := client(t)
+	now := time.Now().UTC()
+
+	c.tracker.observe(c, 1, []*tripObservation{
+		{TripID: "a", RouteID: "Q", StopID: "R20N", Arrival: now.Add(5 * time.Minute)},
+		{TripID: "b", RouteID: "Q", StopID: "R20N", Arrival: now.Add(30 * time.Second)},
+		{TripID: "c", RouteID: "Q", StopID: "R20S", Arrival: now.Add(2 * time.Minute)},
+	}, now)
+	// Another feed must not affect trips of feed 1.
+	c.tracker.observe(c, 2, nil, now)
+
+	// a vanishes well before its arrival; b is about to arrive.
+	next := now.Add(refreshInterval)
+	c.tracker.observe(c, 1, []*tripObservation{
+		{TripID: "c", RouteID: "Q", StopID: "R20S", Arrival: next.Add(2 * time.Minute)},
+	}, next)
+
+	later := now.Add(stallAfter + time.Minute)
+	c.tracker.observe(c, 1, []*tripObservation{
+		{TripID: "c", RouteID: "Q", StopID: "R20S", Arrival: later.Add(2 * time.Minute)},
+	}, later)
+
+	anomalies := c.GetAnomalies(nil)
+	if len(anomalies) != 2 {
+		t.Fatalf("anomalies got %v, want %v", len(anomalies), 2)
+	}
+	kinds := map[AnomalyKind]string{}
+	for _, v := range anomalies {
+		kinds[v.Kind] = v.TripIDs[0]
+		if v.StationID != "L03" {
+			t.Errorf("%s StationID got %v, want %v", v.Kind, v.StationID, "L03")
+		}
+	}
+	if kinds[AnomalyGhost] != "a" {
+		t.Errorf("ghost got %v, want %v", kinds[AnomalyGhost], "a")
+	}
+	if kinds[AnomalyStalled] != "c" {
+		t.Errorf("stalled got %v, want %v", kinds[AnomalyStalled], "c")
+	}
+	if len(c.GetAnomalies([]string{"N"})) != 0 {
+		t.Error("GetAnomalies expected no anomalies for N")
+	}
+}
+
+func TestTrackerBunching(t *testing.T) {
+	c := client(t)
+	now := time.Now().UTC()
+	at := func(s int) *time.Time {
+		t := now.Add(time.Duration(s) * time.Second)
+		return &t
+	}
+	station, _ := c.GetStation("L03")
+	station.Arrivals["N"] = []*Arrival{
+		{TripID: "a", RouteID: "Q", Time: at(60)},
+		{TripID: "x", RouteID: "N", Time: at(90)},
+		{TripID: "b", RouteID: "Q", Time: at(120)},
+		{TripID: "c", RouteID: "Q", Time: at(600)},
+	}
+
+	c.tracker.bunching(c, now)
+	c.tracker.bunching(c, now.Add(refreshInterval))
+
+	anomalies := c.GetAnomalies(nil)
+	if len(anomalies) != 1 {
+		t.Fatalf("anomalies got %v, want %v", len(anomalies), 1)
+	}
+	if v := anomalies[0]; v.Kind != AnomalyBunching || v.TripIDs[0] != "a" || v.TripIDs[1] != "b" {
+		t.Errorf("anomaly got %v %v, want bunching [a b]", v.Kind, v.TripIDs)
+	}
+}
@@ -0,0 +1,48 @@
+package mta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+)
+
+func BenchmarkRefreshFeed(b *testing.B) {
+	srv, c := standIn(b, time.Now())
+	defer srv.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, feedID := range feedIDs {
+			// Forget the last fetch so the feed is processed again.
+			c.polls[feedID] = &feedPoll{}
+			if _, err := c.refreshFeed(context.Background(), feedID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkIngest(b *testing.B) {
+	now := time.Now()
+	feeds := make(map[int]*gtfs.FeedMessage)
+	for _, feedID := range feedIDs {
+		feed := &gtfs.FeedMessage{}
+		if err := proto.Unmarshal(feedFixture(b, feedID, now), feed); err != nil {
+			b.Fatal(err)
+		}
+		feeds[feedID] = feed
+	}
+	c := client(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, feedID := range feedIDs {
+			c.ingest(feedID, feeds[feedID], now)
+		}
+	}
+}
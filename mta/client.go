@@ -1,19 +1,29 @@
 package mta
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 
 	raven "github.com/getsentry/raven-go"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
 	"github.com/kyroy/kdtree"
 )
 
 const (
 	feedBaseURL     = "http://datamine.mta.info/mta_esi.php"
 	refreshInterval = time.Second * 5
+	// feedTimeout bounds a feed request, including reading the body.
+	feedTimeout = time.Second * 10
+	// maxBackoff bounds the wait before retrying a failing feed.
+	maxBackoff = time.Minute * 2
+	// defaultStaleAfter is how long a feed may not update a prediction
+	// before it is stale.
+	defaultStaleAfter = time.Minute * 2
 )
 
 // datamine.mta.info/list-of-feeds
@@ -21,19 +31,47 @@ var feedIDs = []int{1, 2, 16, 21, 26, 31, 36, 51}
 
 // Client consumes the MTA API.
 type Client struct {
-	apiKey    string
-	client    *http.Client
-	ignoreSSL bool
-	port      int
+	apiKey           string
+	feedURL          string
+	serviceStatusURL string
+	client           *http.Client
+	ignoreSSL        bool
+	port             int
+	staleAfter       time.Duration
 
 	stops    map[string]StationID
 	stations Stations
 	tree     *kdtree.KDTree
+	routes   []*Route
+	feeds    map[int]*gtfs.FeedMessage
+	fetched  map[int]time.Time
+	polls    map[int]*feedPoll
+	// indexes holds the arrivals each feed predicts; they are replaced
+	// under mergeMtx.
+	indexes  map[int]feedIndex
+	mergeMtx *sync.Mutex
+	schedule *schedule
+	tracker  *tracker
+	recorder *recorder
+	accuracy *accuracyCache
 	mtx      *sync.Mutex
 
 	err     chan error
-	done    chan struct{}
 	updated *time.Time
+	service *Service
+	// alertWork and statusWork are the planned work announced by the
+	// alerts of each feed and by the service status.
+	alertWork  map[int][]*PlannedWork
+	statusWork []*PlannedWork
+	history    *statusHistory
+
+	// cancel stops Work, which working waits for.
+	cancel  context.CancelFunc
+	closed  bool
+	working sync.WaitGroup
+
+	subs    map[*Subscription]struct{}
+	subsMtx *sync.Mutex
 }
 
 // ClientConfig defines the settings for the MTA client.
@@ -43,6 +81,30 @@ type ClientConfig struct {
 	Port              int
 	StopsFilePath     string
 	TransfersFilePath string
+	RoutesFilePath    string
+
+	// The static timetable is optional; scheduled headways are unknown
+	// without it.
+	TripsFilePath         string
+	CalendarFilePath      string
+	CalendarDatesFilePath string
+	StopTimesFilePath     string
+
+	// RecordPath is the database predictions, observed arrivals and
+	// changes of service status are recorded to; nothing is recorded when
+	// empty.
+	RecordPath string
+	// RecordRetention is how long recorded predictions and arrivals are
+	// kept; zero is 30 days.
+	RecordRetention time.Duration
+
+	// FeedURL and ServiceStatusURL override the endpoints the feeds and
+	// service status are fetched from, e.g., to fetch from a stand-in.
+	FeedURL          string
+	ServiceStatusURL string
+	// StaleAfter is how long a feed may not update a prediction before the
+	// arrival is stale; zero is two minutes.
+	StaleAfter time.Duration
 }
 
 // NewClient returns a new instance of the MTA client.
@@ -53,53 +115,193 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 		return nil, err
 	}
 	c := &Client{
-		apiKey:   cfg.APIKey,
-		done:     make(chan struct{}),
-		err:      make(chan error),
-		mtx:      &sync.Mutex{},
-		port:     cfg.Port,
-		stations: result.Stations,
-		stops:    result.StationMap,
-		tree:     result.Tree,
+		alertWork:        make(map[int][]*PlannedWork),
+		apiKey:           cfg.APIKey,
+		err:              make(chan error),
+		feeds:            make(map[int]*gtfs.FeedMessage),
+		feedURL:          feedBaseURL,
+		serviceStatusURL: serviceStatusURL,
+		fetched:          make(map[int]time.Time),
+		history:          newStatusHistory(),
+		indexes:          make(map[int]feedIndex),
+		mergeMtx:         &sync.Mutex{},
+		polls:            make(map[int]*feedPoll),
+		ignoreSSL:        cfg.IgnoreSSL,
+		mtx:              &sync.Mutex{},
+		port:             cfg.Port,
+		stations:         result.Stations,
+		stops:            result.StationMap,
+		subs:             make(map[*Subscription]struct{}),
+		subsMtx:          &sync.Mutex{},
+		tree:             result.Tree,
+		tracker:          newTracker(),
+	}
+	if cfg.FeedURL != "" {
+		c.feedURL = cfg.FeedURL
+	}
+	if cfg.ServiceStatusURL != "" {
+		c.serviceStatusURL = cfg.ServiceStatusURL
+	}
+	c.staleAfter = cfg.StaleAfter
+	if c.staleAfter == 0 {
+		c.staleAfter = defaultStaleAfter
+	}
+	for _, feedID := range feedIDs {
+		c.polls[feedID] = &feedPoll{}
+	}
+	// The feeds are fetched concurrently, so the HTTP client is not made
+	// lazily.
+	c.httpClient()
+	if cfg.RoutesFilePath != "" {
+		c.routes, err = parseRoutes(cfg.RoutesFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.StopTimesFilePath != "" {
+		sp := &ScheduleParser{cfg.TripsFilePath, cfg.CalendarFilePath, cfg.CalendarDatesFilePath, cfg.StopTimesFilePath}
+		c.schedule, err = sp.Parse(result.StationMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RecordPath != "" {
+		store, err := OpenStore(cfg.RecordPath, false)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := store.StatusChanges()
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+		c.history.load(changes, time.Now().UTC())
+		c.recorder = newRecorder(store, cfg.RecordRetention)
+		c.accuracy = newAccuracyCache(store)
 	}
 	return c, nil
 }
 
-// Close terminates the session.
+// Close stops Work, waiting for it to return, then writes any pending
+// records. It is safe to call whether or not Work is running.
 func (c *Client) Close() error {
-	c.done <- struct{}{}
+	c.mtx.Lock()
+	c.closed = true
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.mtx.Unlock()
+	c.working.Wait()
+
+	if c.recorder != nil {
+		return c.recorder.Close()
+	}
 	return nil
 }
 
-// Work starts the feed fetcher.
-func (c *Client) Work() {
-	raven.CapturePanic(func() {
-		c.refreshFeeds()
-		ticker := time.NewTicker(refreshInterval)
-		for {
-			select {
-			case <-ticker.C:
-				c.refreshFeeds()
-			case <-c.done:
-				ticker.Stop()
-				return
-			}
-		}
-	}, nil)
-}
+// Work refreshes the feeds and service status until ctx is done or the
+// client is closed, cancelling any fetches in flight. Each feed is polled
+// on its own, so a slow or failing feed does not delay the others. It must
+// not be called more than once.
+func (c *Client) Work(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.mtx.Lock()
+	if c.closed {
+		c.mtx.Unlock()
+		return
+	}
+	c.cancel = cancel
+	c.working.Add(1)
+	c.mtx.Unlock()
+	defer c.working.Done()
 
-func (c *Client) refreshFeeds() {
 	var wg sync.WaitGroup
-	wg.Add(len(feedIDs))
+	wg.Add(len(feedIDs) + 1)
+	go func() {
+		defer wg.Done()
+		raven.CapturePanic(func() { c.pollServiceStatus(ctx) }, nil)
+	}()
 	for _, feedID := range feedIDs {
 		go func(feedID int) {
 			defer wg.Done()
-			c.refreshFeed(feedID)
+			raven.CapturePanic(func() { c.pollFeed(ctx, feedID) }, nil)
 		}(feedID)
 	}
 	wg.Wait()
 }
 
+// pollFeed refreshes a feed until ctx is done, waiting the refresh
+// interval after a success and backing off after consecutive failures.
+func (c *Client) pollFeed(ctx context.Context, feedID int) {
+	var failures int
+	for {
+		start := time.Now()
+		changed, err := c.refreshFeed(ctx, feedID)
+		// A cancelled refresh is incomplete.
+		if ctx.Err() != nil {
+			return
+		}
+		delay := refreshInterval
+		if err != nil {
+			failures++
+			delay = backoff(failures)
+			// The arrivals of a failing feed are kept, as stale, until
+			// they pass.
+			if changed := c.expire(feedID, time.Now().UTC()); len(changed) > 0 {
+				c.publish(changed)
+			}
+		} else {
+			failures = 0
+			c.commit(start, changed)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// backoff returns how long to wait after consecutive failures: the
+// refresh interval doubled per failure up to maxBackoff, of which a random
+// half is waited so that feeds failing together spread out.
+func backoff(failures int) time.Duration {
+	d := maxBackoff
+	if failures < 16 {
+		if v := refreshInterval << uint(failures); v < maxBackoff {
+			d = v
+		}
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// commit publishes a successful refresh of a feed, which started at the
+// given time and changed the arrivals of the given stations.
+func (c *Client) commit(start time.Time, changed map[StationID]struct{}) {
+	now := time.Now().UTC()
+	c.mtx.Lock()
+	c.updated = &now
+	c.observeRefresh(start, now)
+	c.mtx.Unlock()
+
+	if len(changed) > 0 {
+		c.tracker.bunching(c, now)
+		c.publish(changed)
+	}
+}
+
+// Updated returns when a feed was last refreshed, or nil before the first
+// refresh.
+func (c *Client) Updated() *time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.updated
+}
+
 func (c *Client) httpClient() *http.Client {
 	if c.client == nil {
 		if c.ignoreSSL {
@@ -115,5 +317,5 @@ func (c *Client) httpClient() *http.Client {
 }
 
 func (c *Client) getFeedURL(feedID int) string {
-	return fmt.Sprintf("%s?&key=%s&feed_id=%d", feedBaseURL, c.apiKey, feedID)
+	return fmt.Sprintf("%s?&key=%s&feed_id=%d", c.feedURL, c.apiKey, feedID)
 }
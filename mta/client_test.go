@@ -0,0 +1,41 @@
+package mta
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClose(t *testing.T) {
+	// Closing a client that never worked must not block.
+	if err := client(t).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, c := standIn(t, time.Now())
+	defer srv.Close()
+	stopped := make(chan struct{})
+	go func() {
+		c.Work(context.Background())
+		close(stopped)
+	}()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Work did not return after Close")
+	}
+}
+
+func TestWorkCancelled(t *testing.T) {
+	srv, c := standIn(t, time.Now())
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Work(ctx)
+	if updated := c.Updated(); updated != nil {
+		t.Errorf("Updated got %v, want nil after a cancelled refresh", updated)
+	}
+}
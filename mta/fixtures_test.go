@@ -0,0 +1,142 @@
+package mta
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+var (
+	update  = flag.Bool("update", false, "regenerate the edge case fixtures")
+	capture = flag.String("capture", "", "API key to capture the feed fixtures from the MTA with")
+)
+
+// The feed fixtures in testdata/feeds are to be the feeds as served by the
+// MTA, captured with -capture; those committed were synthesized from the
+// static GTFS until they are. The edge cases the MTA serves too rarely to
+// be captured on demand are synthesized into testdata/edge with -update.
+const (
+	fixturePath     = "testdata/feeds/%d.pb"
+	edgeFixtureDir  = "testdata/edge"
+	edgeFixtureFeed = 16
+	edgeTimestamp   = 1527854400
+)
+
+func TestCaptureFeeds(t *testing.T) {
+	if *capture == "" {
+		t.Skip("run with -capture=<API key> to capture the feed fixtures")
+	}
+	c := &Client{apiKey: *capture, feedURL: feedBaseURL}
+	for _, feedID := range feedIDs {
+		resp, err := c.httpClient().Get(c.getFeedURL(feedID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("feed %d returned %s", feedID, resp.Status)
+		}
+		// The MTA often serves truncated feeds, which are not kept.
+		if err := proto.Unmarshal(b, &gtfs.FeedMessage{}); err != nil {
+			t.Fatalf("feed %d: %v", feedID, err)
+		}
+		if err := ioutil.WriteFile(fmt.Sprintf(fixturePath, feedID), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEdgeFixtures(t *testing.T) {
+	if !*update {
+		t.Skip("run with -update to regenerate the edge case fixtures")
+	}
+	b, err := proto.Marshal(edgeFeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(edgeFixtureDir, fmt.Sprintf("%d.pb", edgeFixtureFeed)), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// edgeFeed returns a feed of northbound trips at 14 St-Union Sq (R20),
+// 34 St-Herald Sq (R17) and Times Sq-42 St (R16) covering what the index
+// must cope with: stop times out of order, passed arrivals, stops without
+// an arrival, unknown or undirected stops, and a trip predicted twice.
+func edgeFeed() *gtfs.FeedMessage {
+	trip := func(id, routeID string, updates ...*gtfs.TripUpdate_StopTimeUpdate) *gtfs.FeedEntity {
+		return &gtfs.FeedEntity{
+			Id: proto.String(id),
+			TripUpdate: &gtfs.TripUpdate{
+				Trip: &gtfs.TripDescriptor{
+					TripId:    proto.String(id),
+					StartDate: proto.String("20180601"),
+					RouteId:   proto.String(routeID),
+				},
+				StopTimeUpdate: updates,
+			},
+		}
+	}
+	at := func(m int) *gtfs.TripUpdate_StopTimeEvent {
+		return &gtfs.TripUpdate_StopTimeEvent{Time: proto.Int64(edgeTimestamp + int64(m)*60)}
+	}
+	arrive := func(stopID string, m int) *gtfs.TripUpdate_StopTimeUpdate {
+		return &gtfs.TripUpdate_StopTimeUpdate{StopId: proto.String(stopID), Arrival: at(m), Departure: at(m)}
+	}
+	return &gtfs.FeedMessage{
+		Header: &gtfs.FeedHeader{
+			GtfsRealtimeVersion: proto.String("1.0"),
+			Timestamp:           proto.Uint64(edgeTimestamp),
+		},
+		Entity: []*gtfs.FeedEntity{
+			trip("048000_Q..N", "Q", arrive("R16N", 9), arrive("R20N", 3), arrive("R17N", 6)),
+			trip("048400_N..N", "N", arrive("R20N", -2), arrive("R17N", 4)),
+			trip("048800_R..N", "R",
+				// The origin of a trip is only departed from.
+				&gtfs.TripUpdate_StopTimeUpdate{StopId: proto.String("R20N"), Departure: at(1)},
+				arrive("XXXN", 5), arrive("R17", 7), arrive("R17N", 8)),
+			trip("048000_Q..N", "Q", arrive("R20N", 12)),
+		},
+	}
+}
+
+// feedFixture returns the encoded fixture of a feed, with its times moved
+// so the feed was published at now.
+func feedFixture(t testing.TB, feedID int, now time.Time) []byte {
+	b, err := mtatest.LoadFeed("testdata/feeds", feedID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// standIn returns a stand-in for the MTA serving the fixtures, and a
+// client fetching from it.
+func standIn(t testing.TB, now time.Time) (*mtatest.Server, *Client) {
+	srv := mtatest.NewServer()
+	for _, feedID := range feedIDs {
+		srv.SetFeed(feedID, feedFixture(t, feedID, now))
+	}
+	status, err := ioutil.ReadFile("testdata/serviceStatus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetServiceStatus(status)
+
+	c := client(t)
+	c.feedURL = srv.FeedURL
+	c.serviceStatusURL = srv.ServiceStatusURL
+	return srv, c
+}
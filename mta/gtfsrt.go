@@ -0,0 +1,69 @@
+package mta
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+)
+
+const gtfsRealtimeVersion = "1.0"
+
+// EntityType selects the entities of a GTFS-Realtime feed.
+type EntityType int
+
+// The entity types published by the MTA feeds.
+const (
+	TripUpdates EntityType = iota
+	VehiclePositions
+	Alerts
+)
+
+func (t EntityType) matches(e *gtfs.FeedEntity) bool {
+	switch t {
+	case TripUpdates:
+		return e.TripUpdate != nil
+	case VehiclePositions:
+		return e.Vehicle != nil
+	case Alerts:
+		return e.Alert != nil
+	}
+	return false
+}
+
+// GetFeedMessage returns the entities of the given type from the last
+// fetch of every feed as a single feed message. Entity IDs are only unique
+// within a feed, so they are prefixed with the feed ID. The message shares
+// entities with the client and must not be modified.
+func (c *Client) GetFeedMessage(t EntityType) *gtfs.FeedMessage {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var timestamp uint64
+	var entities []*gtfs.FeedEntity
+	for _, feedID := range feedIDs {
+		feed, ok := c.feeds[feedID]
+		if !ok {
+			continue
+		}
+		if ts := feed.GetHeader().GetTimestamp(); ts > timestamp {
+			timestamp = ts
+		}
+		for _, entity := range feed.Entity {
+			if !t.matches(entity) {
+				continue
+			}
+			e := *entity
+			e.Id = proto.String(fmt.Sprintf("%d:%s", feedID, entity.GetId()))
+			entities = append(entities, &e)
+		}
+	}
+	return &gtfs.FeedMessage{
+		Header: &gtfs.FeedHeader{
+			GtfsRealtimeVersion: proto.String(gtfsRealtimeVersion),
+			Incrementality:      gtfs.FeedHeader_FULL_DATASET.Enum(),
+			Timestamp:           proto.Uint64(timestamp),
+		},
+		Entity: entities,
+	}
+}
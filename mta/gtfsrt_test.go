@@ -0,0 +1,71 @@
+package mta
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+)
+
+func feedMessage(timestamp uint64, entities ...*gtfs.FeedEntity) *gtfs.FeedMessage {
+	return &gtfs.FeedMessage{
+		Header: &gtfs.FeedHeader{
+			GtfsRealtimeVersion: proto.String("1.0"),
+			Timestamp:           proto.Uint64(timestamp),
+		},
+		Entity: entities,
+	}
+}
+
+func tripUpdate(id, tripID string) *gtfs.FeedEntity {
+	return &gtfs.FeedEntity{
+		Id:         proto.String(id),
+		TripUpdate: &gtfs.TripUpdate{Trip: &gtfs.TripDescriptor{TripId: proto.String(tripID)}},
+	}
+}
+
+func vehicle(id, tripID string) *gtfs.FeedEntity {
+	return &gtfs.FeedEntity{
+		Id:      proto.String(id),
+		Vehicle: &gtfs.VehiclePosition{Trip: &gtfs.TripDescriptor{TripId: proto.String(tripID)}},
+	}
+}
+
+func TestGetFeedMessage(t *testing.T) {
+	c := client(t)
+	c.feeds[1] = feedMessage(100, tripUpdate("000001", "a"), vehicle("000002", "a"))
+	c.feeds[16] = feedMessage(120, tripUpdate("000001", "b"))
+
+	var tests = []struct {
+		t    EntityType
+		want []string
+	}{
+		{TripUpdates, []string{"1:000001", "16:000001"}},
+		{VehiclePositions, []string{"1:000002"}},
+		{Alerts, nil},
+	}
+	for _, tt := range tests {
+		feed := c.GetFeedMessage(tt.t)
+		if got := feed.GetHeader().GetTimestamp(); got != 120 {
+			t.Errorf("%v timestamp got %v, want %v", tt.t, got, 120)
+		}
+		var got []string
+		for _, e := range feed.Entity {
+			got = append(got, e.GetId())
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%v got %v, want %v", tt.t, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%v got %v, want %v", tt.t, got, tt.want)
+			}
+		}
+	}
+
+	// The client's entities are left as fetched.
+	if got := c.feeds[1].Entity[0].GetId(); got != "000001" {
+		t.Errorf("Id got %v, want %v", got, "000001")
+	}
+}
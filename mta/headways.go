@@ -0,0 +1,73 @@
+package mta
+
+import (
+	"time"
+)
+
+// Headways describes the spacing of trains on a route at a station in one
+// direction.
+type Headways struct {
+	RouteID   string
+	StationID StationID
+	Direction Direction
+	// Predicted are the gaps between consecutive predicted arrivals.
+	Predicted []time.Duration
+	// Scheduled is the median timetabled headway, or zero if unknown.
+	Scheduled time.Duration
+	// Gaps are the predicted gaps exceeding the scheduled headway by more
+	// than the threshold, or nil if the scheduled headway is unknown.
+	Gaps []*Gap
+}
+
+// Gap is a service gap between two consecutive trains.
+type Gap struct {
+	FromTripID, ToTripID string
+	From, To             *time.Time
+	Duration             time.Duration
+}
+
+// GetHeadways returns the headways of a route at a station in each
+// direction, flagging gaps larger than the scheduled headway plus the
+// threshold. Routes are matched by their public route ID, so the headways
+// of the 6 include the 6X.
+func (c *Client) GetHeadways(routeID string, id StationID, threshold time.Duration) ([]*Headways, error) {
+	station, err := c.GetStation(id)
+	if err != nil {
+		return nil, err
+	}
+	routeID = PublicRouteID(routeID)
+
+	now := time.Now().UTC()
+	result := make([]*Headways, 0, 2)
+	for _, direction := range []Direction{"N", "S"} {
+		h := &Headways{RouteID: routeID, StationID: id, Direction: direction}
+		if c.schedule != nil {
+			h.Scheduled = c.schedule.Headway(scheduleKey{routeID, id, direction}, now)
+		}
+
+		var prev *Arrival
+		for _, v := range station.Arrivals[direction] {
+			// Arrivals that passed since the last refresh have left.
+			if PublicRouteID(v.RouteID) != routeID || v.Time == nil || !v.Time.After(now) {
+				continue
+			}
+			if prev != nil {
+				gap := v.Time.Sub(*prev.Time)
+				h.Predicted = append(h.Predicted, gap)
+				if h.Scheduled > 0 && gap > h.Scheduled+threshold {
+					h.Gaps = append(h.Gaps, &Gap{
+						FromTripID: prev.TripID,
+						ToTripID:   v.TripID,
+						From:       prev.Time,
+						To:         v.Time,
+						Duration:   gap,
+					})
+				}
+			}
+			prev = v
+		}
+
+		result = append(result, h)
+	}
+	return result, nil
+}
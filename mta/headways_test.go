@@ -0,0 +1,110 @@
+package mta
+
+import (
+	"testing"
+	"time"
+)
+
+func parseSchedule(t *testing.T) *schedule {
+	p := ScheduleParser{
+		"./testdata/schedule/trips.txt",
+		"./testdata/schedule/calendar.txt",
+		"./testdata/schedule/calendar_dates.txt",
+		"./testdata/schedule/stop_times.txt",
+	}
+	s, err := p.Parse(parse(t).StationMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestScheduleHeadway(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	var tests = []struct {
+		at       time.Time
+		key      scheduleKey
+		expected time.Duration
+	}{
+		{time.Date(2020, 7, 15, 8, 30, 0, 0, loc), scheduleKey{"Q", "L03", "N"}, 6 * time.Minute},
+		{time.Date(2020, 7, 15, 8, 30, 0, 0, loc), scheduleKey{"Q", "L03", "S"}, 0},
+		{time.Date(2020, 7, 15, 8, 30, 0, 0, loc), scheduleKey{"N", "L03", "N"}, 0},
+		{time.Date(2020, 7, 18, 8, 30, 0, 0, loc), scheduleKey{"Q", "L03", "N"}, 0},
+		{time.Date(2020, 12, 25, 8, 30, 0, 0, loc), scheduleKey{"Q", "L03", "N"}, 0},
+	}
+
+	s := parseSchedule(t)
+	for _, tt := range tests {
+		if got := s.Headway(tt.key, tt.at); got != tt.expected {
+			t.Errorf("Headway(%v, %v) got %v, want %v", tt.key, tt.at, got, tt.expected)
+		}
+	}
+}
+
+func TestScheduleAfterMidnight(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	s := parseSchedule(t)
+	from := time.Date(2020, 7, 16, 0, 0, 0, 0, loc)
+	got := s.Between(scheduleKey{"Q", "L03", "N"}, from, from.Add(time.Hour))
+	if len(got) != 1 || !got[0].Equal(from.Add(10*time.Minute)) {
+		t.Errorf("Between got %v, want [%v]", got, from.Add(10*time.Minute))
+	}
+}
+
+func TestGetHeadways(t *testing.T) {
+	c := client(t)
+	loc, _ := time.LoadLocation("America/New_York")
+	// The 6 is scheduled every six minutes northbound, all day, every day.
+	daily := &service{weekdays: [7]bool{true, true, true, true, true, true, true}, start: "00000000", end: "99999999"}
+	var stops []scheduledStop
+	for offset := time.Duration(0); offset < 30*time.Hour; offset += 6 * time.Minute {
+		stops = append(stops, scheduledStop{service: daily, offset: offset})
+	}
+	c.schedule = &schedule{loc: loc, times: map[scheduleKey][]scheduledStop{{"6", "L03", "N"}: stops}}
+
+	station, _ := c.GetStation("L03")
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	station.Arrivals["N"] = []*Arrival{
+		// z has left since the last refresh.
+		{TripID: "z", RouteID: "6", Time: at(-3)},
+		{TripID: "a", RouteID: "6", Time: at(1)},
+		{TripID: "b", RouteID: "N", Time: at(2)},
+		{TripID: "c", RouteID: "6X", Time: at(5)},
+		{TripID: "d", RouteID: "6", Time: at(17)},
+	}
+	station.Arrivals["S"] = []*Arrival{
+		{TripID: "e", RouteID: "6", Time: at(1)},
+		{TripID: "f", RouteID: "6", Time: at(30)},
+	}
+
+	headways, err := c.GetHeadways("6", "L03", 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headways) != 2 {
+		t.Fatalf("headways got %v, want %v", len(headways), 2)
+	}
+	n := headways[0]
+	if n.Scheduled != 6*time.Minute {
+		t.Errorf("Scheduled got %v, want 6m", n.Scheduled)
+	}
+	if len(n.Predicted) != 2 || n.Predicted[0] != 4*time.Minute || n.Predicted[1] != 12*time.Minute {
+		t.Errorf("Predicted got %v, want [4m 12m]", n.Predicted)
+	}
+	if len(n.Gaps) != 1 || n.Gaps[0].FromTripID != "c" || n.Gaps[0].ToTripID != "d" {
+		t.Errorf("Gaps got %v, want c→d", n.Gaps)
+	}
+	// Without a schedule, no gap is flagged.
+	s := headways[1]
+	if s.Scheduled != 0 || len(s.Predicted) != 1 || len(s.Gaps) != 0 {
+		t.Errorf("S got Scheduled %v, Predicted %v, Gaps %v, want 0, [29m], none", s.Scheduled, s.Predicted, s.Gaps)
+	}
+
+	if _, err := c.GetHeadways("6", "foo", 0); err == nil {
+		t.Error("GetHeadways expected error")
+	}
+}
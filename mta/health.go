@@ -0,0 +1,38 @@
+package mta
+
+import "time"
+
+// FeedStatus describes the last successful fetch of a feed.
+type FeedStatus struct {
+	FeedID int
+	// Fetched is when the feed was last fetched successfully, or nil if it
+	// never was.
+	Fetched *time.Time
+	// Timestamp is the header timestamp of the feed last fetched.
+	Timestamp *time.Time
+}
+
+// GetFeedStatus returns the status of every feed.
+func (c *Client) GetFeedStatus() []*FeedStatus {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	result := make([]*FeedStatus, 0, len(feedIDs))
+	for _, feedID := range feedIDs {
+		status := &FeedStatus{FeedID: feedID}
+		if t, ok := c.fetched[feedID]; ok {
+			status.Fetched = &t
+		}
+		if ts := c.feeds[feedID].GetHeader().GetTimestamp(); ts > 0 {
+			t := time.Unix(int64(ts), 0).UTC()
+			status.Timestamp = &t
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// NumStations returns the number of stations loaded from the static GTFS.
+func (c *Client) NumStations() int {
+	return len(c.stations)
+}
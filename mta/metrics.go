@@ -0,0 +1,73 @@
+package mta
+
+import (
+	"time"
+
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+)
+
+var (
+	feedFetchDuration = metrics.Default.NewHistogramVec("mta_feed_fetch_duration_seconds",
+		"Time to fetch and decode a feed.", metrics.DefBuckets, "feed")
+	feedFetches = metrics.Default.NewCounterVec("mta_feed_fetches_total",
+		"Feed fetches by result: ok, not_modified, unchanged, request, status, read or decode.", "feed", "result")
+	serviceStatusFetches = metrics.Default.NewCounterVec("mta_service_status_fetches_total",
+		"Service status fetches by result: ok, request, status, read or decode.", "result")
+	feedTimestamp = metrics.Default.NewGaugeVec("mta_feed_timestamp_seconds",
+		"Header timestamp of the last feed fetched.", "feed")
+	feedAge = metrics.Default.NewGaugeVec("mta_feed_age_seconds",
+		"Age of the header timestamp of the last feed fetched when it was fetched.", "feed")
+	feedEntities = metrics.Default.NewGaugeVec("mta_feed_entities",
+		"Entities in the last feed fetched by type.", "feed", "type")
+	arrivalsGauge = metrics.Default.NewGaugeVec("mta_arrivals",
+		"Arrivals held across all stations.")
+	refreshDuration = metrics.Default.NewHistogramVec("mta_refresh_duration_seconds",
+		"Time to refresh a feed.", metrics.DefBuckets)
+	mergeLockDuration = metrics.Default.NewHistogramVec("mta_merge_lock_seconds",
+		"Time the client is locked to swap in the arrivals of a feed.", metrics.DefBuckets)
+	refreshTimestamp = metrics.Default.NewGaugeVec("mta_refresh_timestamp_seconds",
+		"Time of the last refresh of a feed.")
+	anomaliesDetected = metrics.Default.NewCounterVec("mta_anomalies_total",
+		"Anomalies detected by kind, ghost, stalled or bunching, and route.", "kind", "route")
+	recordDrops = metrics.Default.NewCounterVec("mta_recorder_drops_total",
+		"Feed refreshes not recorded because the recorder fell behind.")
+)
+
+// observeFeed records the header and entity metrics of a feed fetched at
+// the given time.
+func observeFeed(label string, feed *gtfs.FeedMessage, fetched time.Time) {
+	if ts := feed.GetHeader().GetTimestamp(); ts > 0 {
+		header := time.Unix(int64(ts), 0)
+		feedTimestamp.Set(float64(ts), label)
+		feedAge.Set(fetched.Sub(header).Seconds(), label)
+	}
+	var tripUpdates, vehicles, alerts int
+	for _, e := range feed.Entity {
+		switch {
+		case e.TripUpdate != nil:
+			tripUpdates++
+		case e.Vehicle != nil:
+			vehicles++
+		case e.Alert != nil:
+			alerts++
+		}
+	}
+	feedEntities.Set(float64(tripUpdates), label, "trip_update")
+	feedEntities.Set(float64(vehicles), label, "vehicle")
+	feedEntities.Set(float64(alerts), label, "alert")
+}
+
+// observeRefresh records the metrics of a refresh of a feed, which
+// started at the given time. The caller must hold c.mtx.
+func (c *Client) observeRefresh(start, now time.Time) {
+	refreshDuration.Observe(now.Sub(start).Seconds())
+	refreshTimestamp.Set(float64(now.Unix()))
+	var n int
+	for _, station := range c.stations {
+		for _, arrivals := range station.Arrivals {
+			n += len(arrivals)
+		}
+	}
+	arrivalsGauge.Set(float64(n))
+}
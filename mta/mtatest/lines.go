@@ -0,0 +1,210 @@
+package mtatest
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Line is a line synthesized from the static GTFS: the stations a route
+// serves, in order.
+type Line struct {
+	RouteID string
+	// FeedID is the feed predicting the line's arrivals.
+	FeedID int
+	// Stops are the parent stop IDs of the stations, southernmost last.
+	Stops []string
+}
+
+// lineRoutes maps the first character of a stop ID to the route serving
+// those stops and the feed predicting them.
+var lineRoutes = map[byte]struct {
+	routeID string
+	feedID  int
+}{
+	'1': {"1", 1}, '2': {"2", 1}, '3': {"3", 1}, '4': {"4", 1}, '5': {"5", 1}, '6': {"6", 1}, '9': {"GS", 1},
+	'L': {"L", 2},
+	'N': {"N", 16}, 'Q': {"Q", 16}, 'R': {"R", 16},
+	'B': {"B", 21}, 'D': {"D", 21}, 'F': {"F", 21},
+	'A': {"A", 26}, 'H': {"H", 26},
+	'G': {"G", 31},
+	'J': {"J", 36}, 'M': {"M", 36},
+	'7': {"7", 51},
+}
+
+// ReadLines reads the lines of the GTFS in dir, ordered by route. When the
+// GTFS has stop times, each route's stops are those of its southbound trip
+// with the most stops. Otherwise, as stop_times.txt is often not
+// distributed, the lines are approximate: the stations whose stop IDs share
+// a first character, ordered by stop ID, which may skip, misorder or
+// include stations of another route.
+func ReadLines(dir string) ([]*Line, error) {
+	stopTimes := filepath.Join(dir, "stop_times.txt")
+	if _, err := os.Stat(stopTimes); err == nil {
+		return readTripLines(filepath.Join(dir, "trips.txt"), stopTimes)
+	}
+	return readPrefixLines(filepath.Join(dir, "stops.txt"))
+}
+
+// readPrefixLines approximates the lines from a GTFS stops.txt.
+func readPrefixLines(stopsPath string) ([]*Line, error) {
+	f, err := os.Open(stopsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "mtatest: read stops failed")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("mtatest: no stops")
+	}
+
+	byPrefix := make(map[byte]*Line)
+	for _, r := range records[1:] {
+		// Parent stations have a location type of 1.
+		if len(r) < 9 || r[8] != "1" || r[0] == "" {
+			continue
+		}
+		route, ok := lineRoutes[r[0][0]]
+		if !ok {
+			continue
+		}
+		line, ok := byPrefix[r[0][0]]
+		if !ok {
+			line = &Line{RouteID: route.routeID, FeedID: route.feedID}
+			byPrefix[r[0][0]] = line
+		}
+		line.Stops = append(line.Stops, r[0])
+	}
+
+	prefixes := make([]byte, 0, len(byPrefix))
+	for prefix := range byPrefix {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return prefixes[i] < prefixes[j] })
+	lines := make([]*Line, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		line := byPrefix[prefix]
+		sort.Strings(line.Stops)
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// readTripLines builds the lines from the stop times of the routes' trips.
+func readTripLines(tripsPath, stopTimesPath string) ([]*Line, error) {
+	feeds := make(map[string]int, len(lineRoutes))
+	for _, v := range lineRoutes {
+		feeds[v.routeID] = v.feedID
+	}
+
+	routes := make(map[string]string)
+	err := readCSV(tripsPath, []string{"route_id", "trip_id"}, func(r []string) error {
+		if _, ok := feeds[r[0]]; ok {
+			routes[r[1]] = r[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type stopTime struct {
+		sequence int
+		stopID   string
+	}
+	trips := make(map[string][]stopTime)
+	err = readCSV(stopTimesPath, []string{"trip_id", "stop_id", "stop_sequence"}, func(r []string) error {
+		if _, ok := routes[r[0]]; !ok {
+			return nil
+		}
+		sequence, err := strconv.Atoi(r[2])
+		if err != nil {
+			return errors.Wrapf(err, "mtatest: trip %s", r[0])
+		}
+		trips[r[0]] = append(trips[r[0]], stopTime{sequence, r[1]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	longest := make(map[string][]stopTime)
+	for tripID, stops := range trips {
+		sort.Slice(stops, func(i, j int) bool { return stops[i].sequence < stops[j].sequence })
+		// Southbound stop IDs end in S, e.g., 101S.
+		if !strings.HasSuffix(stops[0].stopID, "S") {
+			continue
+		}
+		routeID := routes[tripID]
+		if len(stops) > len(longest[routeID]) {
+			longest[routeID] = stops
+		}
+	}
+
+	lines := make([]*Line, 0, len(longest))
+	for routeID, stops := range longest {
+		line := &Line{RouteID: routeID, FeedID: feeds[routeID]}
+		for _, v := range stops {
+			line.Stops = append(line.Stops, strings.TrimSuffix(v.stopID, "S"))
+		}
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].RouteID < lines[j].RouteID })
+	return lines, nil
+}
+
+// readCSV calls fn with the named columns of each record of a CSV file
+// with a header.
+func readCSV(path string, columns []string, fn func([]string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return errors.Wrapf(err, "mtatest: read %s failed", filepath.Base(path))
+	}
+	index := make([]int, len(columns))
+	for i, name := range columns {
+		index[i] = -1
+		// The header may begin with a byte order mark.
+		for j, v := range header {
+			if strings.TrimPrefix(v, "\ufeff") == name {
+				index[i] = j
+			}
+		}
+		if index[i] < 0 {
+			return errors.Errorf("mtatest: %s has no %s", filepath.Base(path), name)
+		}
+	}
+	values := make([]string, len(columns))
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "mtatest: read %s failed", filepath.Base(path))
+		}
+		for i, j := range index {
+			if j >= len(record) {
+				return errors.Errorf("mtatest: %s has a short record", filepath.Base(path))
+			}
+			values[i] = record[j]
+		}
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package mtatest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadLines(t *testing.T) {
+	// The route's longest southbound trip orders its stops; routes
+	// without a feed are left out.
+	lines, err := ReadLines("testdata/gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Line{{RouteID: "L", FeedID: 2, Stops: []string{"L01", "L02", "L03", "L05"}}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines got %+v, want %+v", lines[0], want[0])
+	}
+
+	// Without stop times, lines are approximated from the stop IDs.
+	lines, err = ReadLines("../testdata/gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range lines {
+		if v.RouteID == "L" && v.Stops[0] != "L01" {
+			t.Errorf("L stops got %v, want L01 first", v.Stops)
+		}
+	}
+	if len(lines) != len(lineRoutes) {
+		t.Errorf("lines got %v, want %v", len(lines), len(lineRoutes))
+	}
+}
@@ -0,0 +1,174 @@
+// Package mtatest provides a stand-in for the MTA endpoints the client
+// fetches from, serving feed fixtures and service status, for tests and
+// benchmarks.
+package mtatest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/pkg/errors"
+)
+
+// The paths of the MTA endpoints.
+const (
+	FeedPath          = "/mta_esi.php"
+	ServiceStatusPath = "/status/serviceStatus.txt"
+)
+
+// Server serves feeds by feed_id and the service status as the MTA does.
+// A feed or the service status may be replaced, or made to fail, at any
+// time.
+type Server struct {
+	*httptest.Server
+	// FeedURL and ServiceStatusURL are the URLs of the endpoints.
+	FeedURL          string
+	ServiceStatusURL string
+
+	mtx      sync.Mutex
+	feeds    map[int][]byte
+	status   []byte
+	failures map[string]int
+	requests map[string]int
+}
+
+// NewServer starts a server with no feeds, which are not found until set.
+func NewServer() *Server {
+	s := &Server{
+		feeds:    make(map[int][]byte),
+		failures: make(map[string]int),
+		requests: make(map[string]int),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(FeedPath, s.serveFeed)
+	mux.HandleFunc(ServiceStatusPath, s.serveServiceStatus)
+	s.Server = httptest.NewServer(mux)
+	s.FeedURL = s.URL + FeedPath
+	s.ServiceStatusURL = s.URL + ServiceStatusPath
+	return s
+}
+
+// SetFeed sets the encoded message served for a feed.
+func (s *Server) SetFeed(feedID int, b []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.feeds[feedID] = b
+}
+
+// SetServiceStatus sets the service status served.
+func (s *Server) SetServiceStatus(b []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.status = b
+}
+
+// FailFeed makes requests for a feed fail with the status code, or succeed
+// again with a code of zero.
+func (s *Server) FailFeed(feedID int, code int) {
+	s.fail(strconv.Itoa(feedID), code)
+}
+
+// FailServiceStatus makes requests for the service status fail with the
+// status code, or succeed again with a code of zero.
+func (s *Server) FailServiceStatus(code int) {
+	s.fail(ServiceStatusPath, code)
+}
+
+func (s *Server) fail(key string, code int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if code == 0 {
+		delete(s.failures, key)
+		return
+	}
+	s.failures[key] = code
+}
+
+// Requests returns the number of requests made for a feed.
+func (s *Server) Requests(feedID int) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.requests[strconv.Itoa(feedID)]
+}
+
+func (s *Server) serveFeed(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("feed_id")
+	s.mtx.Lock()
+	s.requests[key]++
+	code := s.failures[key]
+	feedID, _ := strconv.Atoi(key)
+	b, ok := s.feeds[feedID]
+	s.mtx.Unlock()
+
+	switch {
+	case code != 0:
+		http.Error(w, http.StatusText(code), code)
+	case !ok:
+		http.NotFound(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/x-google-protobuf")
+		w.Write(b)
+	}
+}
+
+func (s *Server) serveServiceStatus(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	code := s.failures[ServiceStatusPath]
+	b := s.status
+	s.mtx.Unlock()
+
+	switch {
+	case code != 0:
+		http.Error(w, http.StatusText(code), code)
+	case b == nil:
+		http.NotFound(w, r)
+	default:
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(b)
+	}
+}
+
+// LoadFeed reads the feed fixture <dir>/<feedID>.pb, with its times moved
+// so it was published at now.
+func LoadFeed(dir string, feedID int, now time.Time) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, fmt.Sprintf("%d.pb", feedID)))
+	if err != nil {
+		return nil, err
+	}
+	return Rebase(b, now)
+}
+
+// Rebase moves the times of an encoded feed message so it was published at
+// now, keeping its predictions in the future.
+func Rebase(b []byte, now time.Time) ([]byte, error) {
+	feed := &gtfs.FeedMessage{}
+	if err := proto.Unmarshal(b, feed); err != nil {
+		return nil, errors.Wrap(err, "mtatest: unmarshal failed")
+	}
+	shift := now.Unix() - int64(feed.GetHeader().GetTimestamp())
+	feed.Header.Timestamp = proto.Uint64(uint64(now.Unix()))
+	for _, e := range feed.Entity {
+		if v := e.Vehicle; v != nil && v.Timestamp != nil {
+			v.Timestamp = proto.Uint64(uint64(int64(v.GetTimestamp()) + shift))
+		}
+		if u := e.TripUpdate; u != nil {
+			for _, s := range u.StopTimeUpdate {
+				if s.Arrival != nil {
+					s.Arrival.Time = proto.Int64(s.Arrival.GetTime() + shift)
+				}
+				if s.Departure != nil {
+					s.Departure.Time = proto.Int64(s.Departure.GetTime() + shift)
+				}
+			}
+		}
+	}
+	return proto.Marshal(feed)
+}
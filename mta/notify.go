@@ -0,0 +1,111 @@
+package mta
+
+import "sync"
+
+// Subscription receives the IDs of stations whose arrivals were refreshed
+// and, if subscribed to, the changes of service status. Updates are merged
+// until taken, so a slow subscriber never blocks the refresh loop or
+// misses a station.
+type Subscription struct {
+	// C is signalled whenever updates are pending.
+	C <-chan struct{}
+
+	c chan struct{}
+	// status is set if the changes of service status are collected, which,
+	// unlike stations, accumulate until taken.
+	status  bool
+	mtx     sync.Mutex
+	pending map[StationID]struct{}
+	changes []*StatusChange
+}
+
+// Updated takes the IDs of the stations refreshed since the last call.
+func (s *Subscription) Updated() []StationID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ids := make([]StationID, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	s.pending = make(map[StationID]struct{})
+	return ids
+}
+
+// StatusChanges takes the changes of service status since the last call,
+// in the order they occurred. It returns none unless the subscription was
+// made by SubscribeStatus.
+func (s *Subscription) StatusChanges() []*StatusChange {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	changes := s.changes
+	s.changes = nil
+	return changes
+}
+
+func (s *Subscription) notify(ids map[StationID]struct{}, changes []*StatusChange) {
+	if !s.status {
+		changes = nil
+	}
+	if len(ids) == 0 && len(changes) == 0 {
+		return
+	}
+	s.mtx.Lock()
+	for id := range ids {
+		s.pending[id] = struct{}{}
+	}
+	s.changes = append(s.changes, changes...)
+	s.mtx.Unlock()
+	select {
+	case s.c <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe returns a subscription to station updates.
+func (c *Client) Subscribe() *Subscription {
+	return c.subscribe(false)
+}
+
+// SubscribeStatus returns a subscription to station updates and changes of
+// service status, which must be taken by StatusChanges.
+func (c *Client) SubscribeStatus() *Subscription {
+	return c.subscribe(true)
+}
+
+func (c *Client) subscribe(status bool) *Subscription {
+	ch := make(chan struct{}, 1)
+	s := &Subscription{C: ch, c: ch, status: status, pending: make(map[StationID]struct{})}
+	c.subsMtx.Lock()
+	c.subs[s] = struct{}{}
+	c.subsMtx.Unlock()
+	return s
+}
+
+// Unsubscribe stops updates to the subscription.
+func (c *Client) Unsubscribe(s *Subscription) {
+	c.subsMtx.Lock()
+	delete(c.subs, s)
+	c.subsMtx.Unlock()
+}
+
+func (c *Client) publish(ids map[StationID]struct{}) {
+	if len(ids) == 0 {
+		return
+	}
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	for s := range c.subs {
+		s.notify(ids, nil)
+	}
+}
+
+func (c *Client) publishStatus(changes []*StatusChange) {
+	if len(changes) == 0 {
+		return
+	}
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	for s := range c.subs {
+		s.notify(nil, changes)
+	}
+}
@@ -0,0 +1,55 @@
+package mta
+
+import "testing"
+
+func TestSubscription(t *testing.T) {
+	c := client(t)
+	s := c.SubscribeStatus()
+	// stations is not subscribed to changes of service status.
+	stations := c.Subscribe()
+
+	c.publish(map[StationID]struct{}{"L03": {}})
+	c.publish(map[StationID]struct{}{"L03": {}, "127": {}})
+	select {
+	case <-s.C:
+	default:
+		t.Fatal("subscription was not signalled")
+	}
+	<-stations.C
+	if ids := s.Updated(); len(ids) != 2 {
+		t.Errorf("Updated got %v, want [L03 127]", ids)
+	}
+	if ids := s.Updated(); len(ids) != 0 {
+		t.Errorf("Updated got %v, want none", ids)
+	}
+
+	changes := []*StatusChange{{Line: "L", Category: StatusDelays}, {Line: "L", Category: StatusGoodService}}
+	c.publishStatus(changes[:1])
+	c.publishStatus(changes[1:])
+	<-s.C
+	if got := s.StatusChanges(); len(got) != 2 || got[0] != changes[0] || got[1] != changes[1] {
+		t.Errorf("StatusChanges got %v, want %v", got, changes)
+	}
+	if ids := s.Updated(); len(ids) != 0 {
+		t.Errorf("Updated got %v, want none", ids)
+	}
+	select {
+	case <-stations.C:
+		t.Error("stations: signalled of changes of service status")
+	default:
+	}
+	if ids := stations.Updated(); len(ids) != 2 {
+		t.Errorf("stations: Updated got %v, want [L03 127]", ids)
+	}
+	if got := stations.StatusChanges(); len(got) != 0 {
+		t.Errorf("stations: StatusChanges got %v, want none", got)
+	}
+
+	c.Unsubscribe(s)
+	c.publish(map[StationID]struct{}{"L03": {}})
+	select {
+	case <-s.C:
+		t.Error("unsubscribed subscription was signalled")
+	default:
+	}
+}
@@ -13,10 +13,11 @@ func parse(t *testing.T) *parseResult {
 	return res
 }
 
-func client(t *testing.T) *Client {
+func client(t testing.TB) *Client {
 	client, err := NewClient(&ClientConfig{
 		StopsFilePath:     "./testdata/gtfs/stops.txt",
 		TransfersFilePath: "./testdata/gtfs/transfers.txt",
+		RoutesFilePath:    "./testdata/gtfs/routes.txt",
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,360 @@
+package mta
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+)
+
+// PlannedWork is work or a service change affecting routes over periods
+// of time, announced by an alert in a feed or by the service status.
+type PlannedWork struct {
+	// ID identifies the work across refreshes: the feed and entity of an
+	// alert, e.g., 16:alert1, or the line of the service status, e.g.,
+	// status:ACE.
+	ID       string
+	Category StatusCategory
+	Routes   []string
+	Stations []StationID
+	Header   string
+	// Description is plain text.
+	Description string
+	Periods     []*Period
+}
+
+// Period is a span of time; a nil start or end is unbounded.
+type Period struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// overlaps returns whether the period overlaps [from, to).
+func (p *Period) overlaps(from, to time.Time) bool {
+	return (p.Start == nil || p.Start.Before(to)) && (p.End == nil || p.End.After(from))
+}
+
+// GetPlannedWork returns the planned work affecting any of the routes, or
+// all routes if none are given, between from and to, ordered by start.
+// Routes match by public route ID, e.g., work on the 6X affects the 6.
+// Only the periods of the work within [from, to) are returned.
+func (c *Client) GetPlannedWork(routes []string, from, to time.Time) []*PlannedWork {
+	c.mtx.Lock()
+	all := make([]*PlannedWork, 0, len(c.statusWork))
+	for _, feedID := range feedIDs {
+		all = append(all, c.alertWork[feedID]...)
+	}
+	all = append(all, c.statusWork...)
+	c.mtx.Unlock()
+
+	var result []*PlannedWork
+	for _, w := range all {
+		if len(routes) > 0 && !servesAny(w.Routes, routes) {
+			continue
+		}
+		var periods []*Period
+		for _, p := range w.Periods {
+			if p.overlaps(from, to) {
+				periods = append(periods, p)
+			}
+		}
+		if len(periods) == 0 {
+			continue
+		}
+		v := *w
+		v.Periods = periods
+		result = append(result, &v)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].Periods[0].Start, result[j].Periods[0].Start
+		return a == nil && b != nil || a != nil && b != nil && a.Before(*b)
+	})
+	return result
+}
+
+// alertPlannedWork returns the planned work announced by the alerts of a feed
+// with active periods not over by now.
+func (c *Client) alertPlannedWork(feedID int, feed *gtfs.FeedMessage, now time.Time) []*PlannedWork {
+	var result []*PlannedWork
+	for _, entity := range feed.Entity {
+		alert := entity.GetAlert()
+		if alert == nil {
+			continue
+		}
+		w := &PlannedWork{
+			ID:          fmt.Sprintf("%d:%s", feedID, entity.GetId()),
+			Header:      translation(alert.GetHeaderText()),
+			Description: translation(alert.GetDescriptionText()),
+		}
+		var starts bool
+		for _, r := range alert.ActivePeriod {
+			p := &Period{}
+			if r.Start != nil {
+				start := time.Unix(int64(r.GetStart()), 0).UTC()
+				p.Start = &start
+				starts = starts || start.After(now)
+			}
+			if r.End != nil {
+				end := time.Unix(int64(r.GetEnd()), 0).UTC()
+				p.End = &end
+			}
+			if p.End == nil || p.End.After(now) {
+				w.Periods = append(w.Periods, p)
+			}
+		}
+		if len(w.Periods) == 0 {
+			continue
+		}
+		switch {
+		case alert.GetEffect() == gtfs.Alert_NO_SERVICE:
+			w.Category = StatusSuspended
+		case alert.GetEffect() == gtfs.Alert_SIGNIFICANT_DELAYS:
+			w.Category = StatusDelays
+		case starts:
+			w.Category = StatusPlannedWork
+		default:
+			w.Category = StatusServiceChange
+		}
+		for _, e := range alert.InformedEntity {
+			if routeID := e.GetRouteId(); routeID != "" && !strings2.SliceContains(w.Routes, routeID) {
+				w.Routes = append(w.Routes, routeID)
+			}
+			stopID := e.GetStopId()
+			if station, _, ok := c.stationByStop(stopID); ok {
+				stopID = string(station.ID)
+			}
+			if id, ok := c.stops[stopID]; ok && !containsStation(w.Stations, id) {
+				w.Stations = append(w.Stations, id)
+			}
+		}
+		result = append(result, w)
+	}
+	return result
+}
+
+func containsStation(s []StationID, id StationID) bool {
+	for _, v := range s {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// translation returns the English text of a translated string, or its
+// first translation.
+func translation(s *gtfs.TranslatedString) string {
+	if s == nil || len(s.Translation) == 0 {
+		return ""
+	}
+	for _, t := range s.Translation {
+		if lang := t.GetLanguage(); lang == "" || strings.HasPrefix(lang, "en") {
+			return t.GetText()
+		}
+	}
+	return s.Translation[0].GetText()
+}
+
+var routeRef = regexp.MustCompile(`\[([0-9A-Z]{1,3})\]`)
+
+// statusPlannedWork returns the planned work and service changes of the service
+// status. The routes affected are those named in the description, e.g.,
+// [E], and the periods are parsed from it; without a date the work is in
+// effect from when it was posted until further notice.
+func (c *Client) statusPlannedWork(service *Service) []*PlannedWork {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+	var result []*PlannedWork
+	for _, s := range service.Status {
+		if s.Category != StatusPlannedWork && s.Category != StatusServiceChange {
+			continue
+		}
+		w := &PlannedWork{
+			ID:          "status:" + s.Line,
+			Category:    s.Category,
+			Header:      strings.Title(strings.Replace(string(s.Category), "_", " ", -1)),
+			Description: s.Description,
+		}
+		for _, m := range routeRef.FindAllStringSubmatch(s.Description, -1) {
+			routeID := m[1]
+			if routeID == "SIR" {
+				routeID = "SI"
+			}
+			if strings2.SliceContains(s.Routes, routeID) && !strings2.SliceContains(w.Routes, routeID) {
+				w.Routes = append(w.Routes, routeID)
+			}
+		}
+		if len(w.Routes) == 0 {
+			w.Routes = s.Routes
+		}
+
+		posted := service.Updated
+		if s.Posted != nil {
+			posted = s.Posted
+		}
+		for _, paragraph := range strings.Split(s.Description, "\n") {
+			w.Periods = append(w.Periods, parsePeriods(paragraph, posted.In(loc))...)
+		}
+		if len(w.Periods) == 0 {
+			w.Periods = []*Period{{Start: posted}}
+		}
+		result = append(result, w)
+	}
+	return result
+}
+
+const (
+	monthPattern = `(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)(?:uary|ruary|ch|il|e|y|ust|t|tember|ober|ember)?\.?`
+	clockPattern = `(\d{1,2}(?::\d{2})?\s*[AP]M)`
+	dayPattern   = `(Mon|Tue|Wed|Thu|Fri|Sat|Sun)(?:day|s|sday|nesday|rs|rsday|urday)?`
+)
+
+var (
+	// dateRange matches, e.g., Jun 11 - 15 and Jun 29 - Jul 2.
+	dateRange = regexp.MustCompile(`\b` + monthPattern + `\s+(\d{1,2})(?:\s*-\s*(?:` + monthPattern + `\s+)?(\d{1,2}))?\b`)
+	// weekendSpan matches, e.g., 11:45 PM Fri to 5 AM Mon.
+	weekendSpan = regexp.MustCompile(clockPattern + `\s+` + dayPattern + `\s+to\s+` + clockPattern + `\s+` + dayPattern + `\b`)
+	// dailySpan matches, e.g., 9:45 PM to 5 AM.
+	dailySpan = regexp.MustCompile(clockPattern + `\s+to\s+` + clockPattern)
+	dayRange  = regexp.MustCompile(`\b` + dayPattern + `\s+to\s+` + dayPattern + `\b`)
+	dayName   = regexp.MustCompile(`\b` + dayPattern + `\b`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday, "Mon": time.Monday, "Tue": time.Tuesday, "Wed": time.Wednesday,
+	"Thu": time.Thursday, "Fri": time.Friday, "Sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"Jan": time.January, "Feb": time.February, "Mar": time.March, "Apr": time.April,
+	"May": time.May, "Jun": time.June, "Jul": time.July, "Aug": time.August,
+	"Sep": time.September, "Oct": time.October, "Nov": time.November, "Dec": time.December,
+}
+
+// parsePeriods parses the periods of work described as the MTA does,
+// e.g., "9:45 PM to 5 AM, Mon to Fri, Jun 11 - 15" for nights, "11:45 PM
+// Fri to 5 AM Mon, Jun 15 - 18" for weekends, or "Sat and Sun, Jun 16 -
+// 17" for days, in the location of posted, which dates lacking a year
+// are near.
+func parsePeriods(text string, posted time.Time) []*Period {
+	m := dateRange.FindStringSubmatch(text)
+	if m == nil {
+		return nil
+	}
+	first, last := workDates(m, posted)
+
+	var result []*Period
+	if s := weekendSpan.FindStringSubmatch(text); s != nil {
+		startDay, endDay := weekdays[s[2][:3]], weekdays[s[4][:3]]
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() != startDay {
+				continue
+			}
+			e := d
+			for e.Weekday() != endDay {
+				e = e.AddDate(0, 0, 1)
+			}
+			result = appendPeriod(result, timeOfDay(d, s[1]), timeOfDay(e, s[3]))
+		}
+		return result
+	}
+
+	days := workDays(dailySpan.ReplaceAllString(text, ""))
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if days != nil && !days[d.Weekday()] {
+			continue
+		}
+		if s := dailySpan.FindStringSubmatch(text); s != nil {
+			start, end := timeOfDay(d, s[1]), timeOfDay(d, s[2])
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			result = appendPeriod(result, start, end)
+			continue
+		}
+		// Whole days run together.
+		start, end := d, d.AddDate(0, 0, 1)
+		if n := len(result); n > 0 && result[n-1].End.Equal(start) {
+			result[n-1].End = &end
+			continue
+		}
+		result = appendPeriod(result, start, end)
+	}
+	return result
+}
+
+func appendPeriod(s []*Period, start, end time.Time) []*Period {
+	start, end = start.UTC(), end.UTC()
+	return append(s, &Period{Start: &start, End: &end})
+}
+
+// workDates returns the first and last dates of a date range match,
+// taking the year from posted.
+func workDates(m []string, posted time.Time) (time.Time, time.Time) {
+	day, _ := strconv.Atoi(m[2])
+	first := time.Date(posted.Year(), months[m[1][:3]], day, 0, 0, 0, 0, posted.Location())
+	// Work is announced ahead, so dates long before posting are next year.
+	if first.Before(posted.AddDate(0, -6, 0)) {
+		first = first.AddDate(1, 0, 0)
+	}
+	if m[4] == "" {
+		return first, first
+	}
+	month := first.Month()
+	if m[3] != "" {
+		month = months[m[3][:3]]
+	}
+	day, _ = strconv.Atoi(m[4])
+	last := time.Date(first.Year(), month, day, 0, 0, 0, 0, posted.Location())
+	if last.Before(first) {
+		if m[3] == "" {
+			last = last.AddDate(0, 1, 0)
+		} else {
+			last = last.AddDate(1, 0, 0)
+		}
+	}
+	return first, last
+}
+
+// workDays returns the days of the week named in text, e.g., Mon to Fri or
+// Sat and Sun, or nil if none are.
+func workDays(text string) map[time.Weekday]bool {
+	if m := dayRange.FindStringSubmatch(text); m != nil {
+		days := make(map[time.Weekday]bool)
+		for d, end := weekdays[m[1][:3]], weekdays[m[2][:3]]; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == end {
+				return days
+			}
+		}
+	}
+	var days map[time.Weekday]bool
+	for _, m := range dayName.FindAllStringSubmatch(text, -1) {
+		if days == nil {
+			days = make(map[time.Weekday]bool)
+		}
+		days[weekdays[m[1][:3]]] = true
+	}
+	return days
+}
+
+// timeOfDay returns the time of day, e.g., 9:45 PM, on a date.
+func timeOfDay(date time.Time, clock string) time.Time {
+	clock = strings.Replace(clock, " ", "", -1)
+	layout := "3PM"
+	if strings.Contains(clock, ":") {
+		layout = "3:04PM"
+	}
+	t, err := time.Parse(layout, clock)
+	if err != nil {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location())
+}
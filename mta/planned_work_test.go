@@ -0,0 +1,140 @@
+package mta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+)
+
+func TestParsePeriods(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	posted := time.Date(2018, 6, 11, 21, 45, 0, 0, loc)
+	day := func(d, h, m int) string {
+		return time.Date(2018, 6, d, h, m, 0, 0, loc).Format("Jan 2 15:04")
+	}
+	var tests = []struct {
+		text string
+		want []string
+	}{
+		{"[E] trains run local, 9:45 PM to 5 AM, Mon to Fri, Jun 11 - 15.", []string{
+			day(11, 21, 45) + "-" + day(12, 5, 0),
+			day(12, 21, 45) + "-" + day(13, 5, 0),
+			day(13, 21, 45) + "-" + day(14, 5, 0),
+			day(14, 21, 45) + "-" + day(15, 5, 0),
+			day(15, 21, 45) + "-" + day(16, 5, 0),
+		}},
+		{"No [L] trains, 11:45 PM Fri to 5 AM Mon, Jun 15 - 25.", []string{
+			day(15, 23, 45) + "-" + day(18, 5, 0),
+			day(22, 23, 45) + "-" + day(25, 5, 0),
+		}},
+		{"[G] trains run every 12 minutes, Sat and Sun, Jun 16 - 24.", []string{
+			day(16, 0, 0) + "-" + day(18, 0, 0),
+			day(23, 0, 0) + "-" + day(25, 0, 0),
+		}},
+		{"[Q] trains skip Cortelyou Rd, 10 PM to 5 AM, Jun 29 - Jul 1.", []string{
+			day(29, 22, 0) + "-" + day(30, 5, 0),
+			day(30, 22, 0) + "-" + time.Date(2018, 7, 1, 5, 0, 0, 0, loc).Format("Jan 2 15:04"),
+			time.Date(2018, 7, 1, 22, 0, 0, 0, loc).Format("Jan 2 15:04") + "-" + time.Date(2018, 7, 2, 5, 0, 0, 0, loc).Format("Jan 2 15:04"),
+		}},
+		{"[A] trains run express, January 5.", []string{
+			time.Date(2019, 1, 5, 0, 0, 0, 0, loc).Format("Jan 2 15:04") + "-" + time.Date(2019, 1, 6, 0, 0, 0, 0, loc).Format("Jan 2 15:04"),
+		}},
+		{"Trains run local between Marcy Av 5 and Sunset Park.", nil},
+		{"Some southbound [D] trains are running on the [F] line.", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, p := range parsePeriods(tt.text, posted) {
+			got = append(got, p.Start.In(loc).Format("Jan 2 15:04")+"-"+p.End.In(loc).Format("Jan 2 15:04"))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+// alertFeed returns a feed message announcing planned work on a route.
+func alertFeed(routeID, stopID string, start, end time.Time) *gtfs.FeedMessage {
+	return &gtfs.FeedMessage{
+		Header: &gtfs.FeedHeader{GtfsRealtimeVersion: proto.String("1.0")},
+		Entity: []*gtfs.FeedEntity{{
+			Id: proto.String("alert1"),
+			Alert: &gtfs.Alert{
+				ActivePeriod: []*gtfs.TimeRange{{
+					Start: proto.Uint64(uint64(start.Unix())),
+					End:   proto.Uint64(uint64(end.Unix())),
+				}},
+				InformedEntity: []*gtfs.EntitySelector{
+					{RouteId: proto.String(routeID)},
+					{StopId: proto.String(stopID)},
+				},
+				Effect: gtfs.Alert_MODIFIED_SERVICE.Enum(),
+				HeaderText: &gtfs.TranslatedString{Translation: []*gtfs.TranslatedString_Translation{
+					{Text: proto.String(fmt.Sprintf("[%s] trains run local", routeID)), Language: proto.String("en")},
+				}},
+			},
+		}},
+	}
+}
+
+func TestGetPlannedWork(t *testing.T) {
+	now := time.Date(2018, 6, 13, 12, 0, 0, 0, time.UTC)
+	srv, c := standIn(t, now)
+	defer srv.Close()
+	if err := c.refreshServiceStatus(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	c.alertWork[16] = c.alertPlannedWork(16, alertFeed("Q", "R17N", now.Add(48*time.Hour), now.Add(60*time.Hour)), now)
+	c.alertWork[2] = c.alertPlannedWork(2, alertFeed("L", "L03", now.Add(-48*time.Hour), now.Add(-time.Hour)), now)
+	c.alertWork[1] = c.alertPlannedWork(1, alertFeed("6X", "635S", now.Add(24*time.Hour), now.Add(30*time.Hour)), now)
+
+	week := now.Add(7 * 24 * time.Hour)
+	var tests = []struct {
+		routes   []string
+		from, to time.Time
+		want     []string
+		periods  []int
+	}{
+		// The ACE planned work runs nightly to Jun 16, the BDFM service
+		// change is open ended and the L alert is over.
+		{nil, now, week, []string{"status:BDFM", "status:ACE", "1:alert1", "16:alert1"}, []int{1, 3, 1, 1}},
+		{[]string{"E"}, now, week, []string{"status:ACE"}, []int{3}},
+		{[]string{"A", "C"}, now, week, nil, nil},
+		{[]string{"Q", "L"}, now, week, []string{"16:alert1"}, []int{1}},
+		{[]string{"Q"}, now, now.Add(24 * time.Hour), nil, nil},
+		{[]string{"D", "F"}, now, week, []string{"status:BDFM"}, []int{1}},
+		// Routes match by public route ID.
+		{[]string{"6"}, now, week, []string{"1:alert1"}, []int{1}},
+		{[]string{"4", "6X"}, now, week, []string{"1:alert1"}, []int{1}},
+	}
+	for _, tt := range tests {
+		var got []string
+		var periods []int
+		for _, w := range c.GetPlannedWork(tt.routes, tt.from, tt.to) {
+			got = append(got, w.ID)
+			periods = append(periods, len(w.Periods))
+		}
+		if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(periods, tt.periods) {
+			t.Errorf("%v: got %v %v, want %v %v", tt.routes, got, periods, tt.want, tt.periods)
+		}
+	}
+
+	work := c.GetPlannedWork([]string{"Q"}, now, week)
+	if len(work) != 1 {
+		t.Fatalf("got %v, want 1", len(work))
+	}
+	if got, want := work[0].Stations, []StationID{c.stops["R17"]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stations got %v, want %v", got, want)
+	}
+	if got, want := work[0].Category, StatusPlannedWork; got != want {
+		t.Errorf("Category got %v, want %v", got, want)
+	}
+}
@@ -1,12 +1,13 @@
 package mta
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,34 +16,157 @@ import (
 	"github.com/pkg/errors"
 )
 
-const stopRegex = "(?P<ID>.*)(?P<Direction>[NS])"
+// The classes of feed fetch results.
+const (
+	fetchOK          = "ok"
+	fetchNotModified = "not_modified"
+	fetchUnchanged   = "unchanged"
+	fetchRequest     = "request"
+	fetchStatus      = "status"
+	fetchRead        = "read"
+	fetchDecode      = "decode"
+)
+
+// feedPoll is what is known of the last feed fetched, to make conditional
+// requests and skip unchanged payloads. It is only used by the goroutine
+// polling the feed.
+type feedPoll struct {
+	etag         string
+	lastModified string
+	timestamp    uint64
+}
+
+// fetchFeed fetches and decodes a feed, recording the result in the feed
+// metrics. It returns a nil feed if the feed is unchanged since the last
+// fetch, and an error if the fetch failed.
+func (c *Client) fetchFeed(ctx context.Context, feedID int) (*gtfs.FeedMessage, error) {
+	start := time.Now()
+	feed, class, err := c.getFeed(ctx, feedID, c.polls[feedID])
+	label := strconv.Itoa(feedID)
+	feedFetchDuration.Observe(time.Since(start).Seconds(), label)
+	feedFetches.Inc(label, class)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		// The feeds are often truncated, which is not worth logging.
+		msg := errors.Cause(err).Error()
+		if class != fetchDecode || !strings.HasPrefix(msg, "proto") && !strings.HasPrefix(msg, "unexpected EOF") && !strings.HasPrefix(msg, "bad wiretype") {
+			log.Print(err)
+		}
+		return nil, err
+	}
+	if feed != nil {
+		observeFeed(label, feed, start)
+	}
+	return feed, nil
+}
 
-func (c *Client) refreshFeed(feedID int) {
-	re := regexp.MustCompile(stopRegex)
+// getFeed fetches a feed, returning a nil feed if it is unchanged since
+// the fetch recorded in poll, which is updated.
+func (c *Client) getFeed(ctx context.Context, feedID int, poll *feedPoll) (*gtfs.FeedMessage, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
 	req, _ := http.NewRequest("GET", c.getFeedURL(feedID), nil)
+	req = req.WithContext(ctx)
+	if poll.etag != "" {
+		req.Header.Set("If-None-Match", poll.etag)
+	}
+	if poll.lastModified != "" {
+		req.Header.Set("If-Modified-Since", poll.lastModified)
+	}
 	resp, err := c.httpClient().Do(req)
 	if err != nil {
-		log.Print(errors.Wrap(err, "mta: request failed"))
-		return
+		return nil, fetchRequest, errors.Wrap(err, "mta: request failed")
 	}
 	defer mustClose(resp.Body)
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, fetchNotModified, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fetchStatus, errors.Errorf("mta: feed %d returned %s", feedID, resp.Status)
+	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Print(errors.Wrap(err, "mta: read failed"))
-		return
+		return nil, fetchRead, errors.Wrap(err, "mta: read failed")
 	}
 
-	feed := gtfs.FeedMessage{}
-	err = proto.Unmarshal(body, &feed)
-	if err != nil {
-		msg := err.Error()
-		if !strings.HasPrefix(msg, "proto") && !strings.HasPrefix(msg, "unexpected EOF") && !strings.HasPrefix(msg, "bad wiretype") {
-			log.Print(errors.Wrap(err, "mta: unmarshal failed"))
-		}
-		return
+	feed := &gtfs.FeedMessage{}
+	if err := proto.Unmarshal(body, feed); err != nil {
+		return nil, fetchDecode, errors.Wrap(err, "mta: unmarshal failed")
 	}
+	poll.etag = resp.Header.Get("ETag")
+	poll.lastModified = resp.Header.Get("Last-Modified")
+	// The MTA does not always honor conditional requests.
+	ts := feed.GetHeader().GetTimestamp()
+	if ts != 0 && ts == poll.timestamp {
+		return nil, fetchUnchanged, nil
+	}
+	poll.timestamp = ts
+	return feed, fetchOK, nil
+}
 
+// refreshFeed fetches a feed and returns the stations whose arrivals
+// changed.
+func (c *Client) refreshFeed(ctx context.Context, feedID int) (map[StationID]struct{}, error) {
+	feed, err := c.fetchFeed(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
+	var work []*PlannedWork
+	if feed != nil {
+		work = c.alertPlannedWork(feedID, feed, now)
+	}
+	c.mtx.Lock()
+	c.fetched[feedID] = now
+	if feed != nil {
+		c.feeds[feedID] = feed
+		c.alertWork[feedID] = work
+	}
+	c.mtx.Unlock()
+	if feed == nil {
+		return nil, nil
+	}
+	return c.ingest(feedID, feed, now), nil
+}
+
+// ingest replaces the arrivals predicted by a feed with those of a new
+// message and returns the stations whose arrivals changed.
+func (c *Client) ingest(feedID int, feed *gtfs.FeedMessage, now time.Time) map[StationID]struct{} {
+	idx, trips, predictions := c.index(feedID, feed, now)
+	changed := c.merge(feedID, idx, &now)
+	arrivals := c.tracker.observe(c, feedID, trips, now)
+	c.recorder.enqueue(&recordBatch{predictions: predictions, arrivals: arrivals})
+	return changed
+}
+
+// feedIndex holds the arrivals a feed predicts by station and direction,
+// each list ordered by time. An index is not modified once built, so its
+// lists are shared with the stations.
+type feedIndex map[StationID]map[Direction][]*Arrival
+
+// index builds the index of a feed message in one pass, along with the
+// trips observed and the predictions to record.
+func (c *Client) index(feedID int, feed *gtfs.FeedMessage, now time.Time) (feedIndex, []*tripObservation, []*Prediction) {
+	updated := now
+	if ts := feed.GetHeader().GetTimestamp(); ts > 0 {
+		updated = time.Unix(int64(ts), 0).UTC()
+	}
+	staleAt := updated.Add(c.staleAfter)
+
+	// The arrivals and their times are allocated at once rather than one
+	// by one.
+	var n int
+	for _, entity := range feed.Entity {
+		n += len(entity.GetTripUpdate().GetStopTimeUpdate())
+	}
+	arrivals := make([]Arrival, 0, n)
+	times := make([]time.Time, 0, n)
+
+	idx := make(feedIndex)
+	trips := make([]*tripObservation, 0, len(feed.Entity))
+	var predictions []*Prediction
 	for _, entity := range feed.Entity {
 		tripUpdate := entity.GetTripUpdate()
 		if tripUpdate == nil {
@@ -50,49 +174,192 @@ func (c *Client) refreshFeed(feedID int) {
 		}
 
 		trip := tripUpdate.GetTrip()
-		stopTimeUpdates := tripUpdate.GetStopTimeUpdate()
-		for _, update := range stopTimeUpdates {
+		tripID, routeID, startDate := trip.GetTripId(), trip.GetRouteId(), trip.GetStartDate()
+		observed := false
+		for _, update := range tripUpdate.GetStopTimeUpdate() {
+			arrival := update.GetArrival()
+			if arrival == nil {
+				continue
+			}
 			stopID := update.GetStopId()
-			m := re.FindStringSubmatch(stopID)
-			if m[1] == "" {
+			station, direction, ok := c.stationByStop(stopID)
+			if !ok {
 				continue
 			}
 
-			station, err := c.GetStationByStopID(m[1])
-			if err != nil {
-				continue
+			times = append(times, time.Unix(arrival.GetTime(), 0).UTC())
+			arrivalTime := &times[len(times)-1]
+			if !observed {
+				observed = true
+				trips = append(trips, &tripObservation{
+					TripID:    tripID,
+					StartDate: startDate,
+					RouteID:   routeID,
+					StopID:    stopID,
+					Arrival:   *arrivalTime,
+				})
+			}
+			if c.recorder != nil {
+				predictions = append(predictions, &Prediction{
+					TripID:    tripID,
+					StartDate: startDate,
+					RouteID:   routeID,
+					StopID:    stopID,
+					Time:      *arrivalTime,
+					Observed:  now,
+				})
+			}
+			arrivals = append(arrivals, Arrival{
+				RouteID:   routeID,
+				Time:      arrivalTime,
+				TripID:    tripID,
+				StartDate: startDate,
+				FeedID:    feedID,
+				Updated:   &updated,
+				StaleAt:   staleAt,
+			})
+			byDirection, ok := idx[station.ID]
+			if !ok {
+				byDirection = make(map[Direction][]*Arrival, 2)
+				idx[station.ID] = byDirection
 			}
+			byDirection[direction] = append(byDirection[direction], &arrivals[len(arrivals)-1])
+		}
+	}
+	for _, byDirection := range idx {
+		for d, s := range byDirection {
+			sort.Stable(ByArrivalTime(s))
+			byDirection[d] = cleanupArrivals(s, now)
+		}
+	}
+	return idx, trips, predictions
+}
 
-			direction := Direction(m[2])
-			arrival := update.GetArrival()
-			if arrival != nil {
-				arrivalTime := time.Unix(arrival.GetTime(), 0).UTC()
-				update := &Arrival{
-					RouteID: trip.GetRouteId(),
-					Time:    &arrivalTime,
-					TripID:  trip.GetTripId(),
-				}
-
-				c.mtx.Lock()
-
-				updated := false
-				for k, v := range station.Arrivals[direction] {
-					if v.TripID == update.TripID {
-						station.Arrivals[direction][k] = update
-						updated = true
-						break
-					}
-				}
-				if !updated {
-					station.Arrivals[direction] = append(station.Arrivals[direction], update)
-				}
-				station.Updated = &now
-				station.Arrivals[direction] = cleanupArrivals(station.Arrivals[direction])
-				sort.Sort(ByArrivalTime(station.Arrivals[direction]))
-				c.mtx.Unlock()
+// stationByStop returns the station and direction of a directional GTFS
+// stop ID, e.g., L03N.
+func (c *Client) stationByStop(stopID string) (*Station, Direction, bool) {
+	n := len(stopID)
+	if n < 2 || (stopID[n-1] != 'N' && stopID[n-1] != 'S') {
+		return nil, "", false
+	}
+	id, ok := c.stops[stopID[:n-1]]
+	if !ok {
+		return nil, "", false
+	}
+	station, ok := c.stations[id]
+	return station, Direction(stopID[n-1:]), ok
+}
+
+// merge replaces the index of a feed and rebuilds the arrivals of the
+// stations it predicts arrivals at, or did. Merges are serialized by
+// mergeMtx, so the arrivals are built from the indexes without holding
+// c.mtx, which is only held to swap them in. The stations in the index are
+// marked updated at the given time unless it is nil. It returns the
+// stations whose arrivals changed.
+func (c *Client) merge(feedID int, idx feedIndex, updated *time.Time) map[StationID]struct{} {
+	c.mergeMtx.Lock()
+	defer c.mergeMtx.Unlock()
+
+	prev := c.indexes[feedID]
+	c.indexes[feedID] = idx
+	changed := make(map[StationID]struct{})
+	next := make(map[*Station]map[Direction][]*Arrival, len(idx))
+	rebuild := func(id StationID) {
+		station := c.stations[id]
+		if _, ok := next[station]; ok {
+			return
+		}
+		arrivals := c.stationArrivals(id)
+		if !sameStationArrivals(station.Arrivals, arrivals) {
+			changed[id] = struct{}{}
+		}
+		next[station] = arrivals
+	}
+	for id := range idx {
+		rebuild(id)
+	}
+	for id := range prev {
+		rebuild(id)
+	}
+
+	start := time.Now()
+	c.mtx.Lock()
+	for station, arrivals := range next {
+		station.Arrivals = arrivals
+		if _, ok := idx[station.ID]; ok && updated != nil {
+			station.Updated = updated
+		}
+	}
+	c.mtx.Unlock()
+	mergeLockDuration.Observe(time.Since(start).Seconds())
+	return changed
+}
+
+// expire drops the arrivals that have passed from the index of a feed,
+// which are otherwise kept until the feed is refreshed, and returns the
+// stations whose arrivals changed. The index is copied rather than
+// modified, as its lists are shared with the stations.
+func (c *Client) expire(feedID int, now time.Time) map[StationID]struct{} {
+	c.mergeMtx.Lock()
+	prev := c.indexes[feedID]
+	idx := make(feedIndex, len(prev))
+	expired := false
+	for id, byDirection := range prev {
+		kept := make(map[Direction][]*Arrival, len(byDirection))
+		for d, s := range byDirection {
+			t := cleanupArrivals(append([]*Arrival(nil), s...), now)
+			if len(t) != len(s) {
+				expired = true
+			}
+			if len(t) > 0 {
+				kept[d] = t
 			}
 		}
+		if len(kept) > 0 {
+			idx[id] = kept
+		}
+	}
+	c.mergeMtx.Unlock()
+	if !expired {
+		return nil
+	}
+	// The feed is not refreshed meanwhile, as both are done by its poller.
+	return c.merge(feedID, idx, nil)
+}
+
+// stationArrivals merges the arrivals every feed predicts at a station.
+// The caller must hold mergeMtx.
+func (c *Client) stationArrivals(id StationID) map[Direction][]*Arrival {
+	result := make(map[Direction][]*Arrival, 2)
+	for _, feedID := range feedIDs {
+		for d, s := range c.indexes[feedID][id] {
+			result[d] = mergeArrivals(result[d], s)
+		}
+	}
+	return result
+}
+
+// mergeArrivals merges two lists of arrivals ordered by time. A list is
+// returned as is when the other is empty, so neither may be modified.
+func mergeArrivals(a, b []*Arrival) []*Arrival {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	}
+	result := make([]*Arrival, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].Time.Before(*a[0].Time) {
+			result = append(result, b[0])
+			b = b[1:]
+		} else {
+			result = append(result, a[0])
+			a = a[1:]
+		}
 	}
+	result = append(result, a...)
+	return append(result, b...)
 }
 
 func mustClose(closer io.ReadCloser) {
@@ -0,0 +1,331 @@
+package mta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+func TestGetFeed(t *testing.T) {
+	var (
+		timestamp uint64 = 1
+		status           = http.StatusOK
+		requests  []*http.Request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		etag := `"` + time.Unix(int64(timestamp), 0).Format(time.RFC3339) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		b, _ := proto.Marshal(feedMessage(timestamp))
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	c := client(t)
+	c.feedURL = srv.URL
+	poll := &feedPoll{}
+
+	var tests = []struct {
+		name      string
+		timestamp uint64
+		status    int
+		// etag is false to have the server ignore If-None-Match.
+		etag  bool
+		class string
+		feed  bool
+	}{
+		{"first", 1, 200, true, fetchOK, true},
+		{"not modified", 1, 200, true, fetchNotModified, false},
+		{"unchanged", 1, 200, false, fetchUnchanged, false},
+		{"changed", 2, 200, true, fetchOK, true},
+		{"failed", 2, 500, true, fetchStatus, false},
+	}
+	for _, tt := range tests {
+		timestamp, status = tt.timestamp, tt.status
+		if !tt.etag {
+			poll.etag = ""
+		}
+		feed, class, _ := c.getFeed(context.Background(), 1, poll)
+		if class != tt.class || (feed != nil) != tt.feed {
+			t.Errorf("%s: got %v %v, want %v %v", tt.name, class, feed != nil, tt.class, tt.feed)
+		}
+	}
+	if got := requests[1].Header.Get("If-None-Match"); got == "" {
+		t.Errorf("second request was not conditional")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	var tests = []struct {
+		failures int
+		min, max time.Duration
+	}{
+		{1, refreshInterval, 2 * refreshInterval},
+		{2, 2 * refreshInterval, 4 * refreshInterval},
+		{10, maxBackoff / 2, maxBackoff},
+		{100, maxBackoff / 2, maxBackoff},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			if got := backoff(tt.failures); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) got %v, want between %v and %v", tt.failures, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := client(t)
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	station := c.stations["L03"]
+	arrivals := func(feedID int, tripIDs ...string) feedIndex {
+		byDirection := make(map[Direction][]*Arrival)
+		// Trips arrive in the order of their IDs.
+		for _, id := range tripIDs {
+			byDirection["N"] = append(byDirection["N"], &Arrival{TripID: id, RouteID: "L", Time: at(int(id[0] - 'a' + 1)), FeedID: feedID})
+		}
+		return feedIndex{station.ID: byDirection}
+	}
+	tripIDs := func() []string {
+		var ids []string
+		for _, v := range station.Arrivals["N"] {
+			ids = append(ids, v.TripID)
+		}
+		return ids
+	}
+
+	var tests = []struct {
+		name    string
+		feedID  int
+		idx     feedIndex
+		want    []string
+		changed bool
+	}{
+		{"first", 2, arrivals(2, "a", "b"), []string{"a", "b"}, true},
+		{"other feed", 16, arrivals(16, "c"), []string{"a", "b", "c"}, true},
+		{"same", 2, arrivals(2, "a", "b"), []string{"a", "b", "c"}, false},
+		{"removed", 2, arrivals(2, "b"), []string{"b", "c"}, true},
+		{"none", 2, nil, []string{"c"}, true},
+	}
+	for _, tt := range tests {
+		changed := c.merge(tt.feedID, tt.idx, &now)
+		if _, ok := changed[station.ID]; ok != tt.changed {
+			t.Errorf("%s: changed got %v, want %v", tt.name, ok, tt.changed)
+		}
+		if got := tripIDs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExpire(t *testing.T) {
+	c := client(t)
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	station := c.stations["L03"]
+	c.merge(2, feedIndex{station.ID: {
+		"N": {{TripID: "a", RouteID: "L", Time: at(1)}, {TripID: "b", RouteID: "L", Time: at(3)}},
+		"S": {{TripID: "c", RouteID: "L", Time: at(2)}},
+	}}, &now)
+	updated := station.Updated
+
+	var tests = []struct {
+		name    string
+		at      time.Time
+		want    map[Direction]int
+		changed bool
+	}{
+		{"upcoming", now, map[Direction]int{"N": 2, "S": 1}, false},
+		{"passed", *at(2), map[Direction]int{"N": 1}, true},
+		{"all passed", *at(5), map[Direction]int{}, true},
+	}
+	for _, tt := range tests {
+		changed := c.expire(2, tt.at)
+		if _, ok := changed[station.ID]; ok != tt.changed {
+			t.Errorf("%s: changed got %v, want %v", tt.name, ok, tt.changed)
+		}
+		got := make(map[Direction]int)
+		for d, s := range station.Arrivals {
+			got[d] = len(s)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		// Expiring is not a refresh.
+		if station.Updated != updated {
+			t.Errorf("%s: Updated got %v, want %v", tt.name, station.Updated, updated)
+		}
+	}
+}
+
+func TestStale(t *testing.T) {
+	now := time.Now()
+	updated := now.Add(-time.Minute)
+	a := &Arrival{Updated: &updated, StaleAt: updated.Add(defaultStaleAfter)}
+	if got, want := a.Age(now), time.Minute; got != want {
+		t.Errorf("Age got %v, want %v", got, want)
+	}
+	if a.Stale(now) {
+		t.Errorf("Stale got true, want false")
+	}
+	if !a.Stale(now.Add(defaultStaleAfter)) {
+		t.Errorf("Stale after %v got false, want true", defaultStaleAfter)
+	}
+}
+
+func TestStationByStop(t *testing.T) {
+	c := client(t)
+	var tests = []struct {
+		stopID    string
+		station   StationID
+		direction Direction
+		ok        bool
+	}{
+		{"L03N", "L03", "N", true},
+		// 635 is part of the Union Sq complex.
+		{"635S", "L03", "S", true},
+		{"L03", "", "", false},
+		{"N", "", "", false},
+		{"", "", "", false},
+		{"XXXN", "", "", false},
+	}
+	for _, tt := range tests {
+		station, direction, ok := c.stationByStop(tt.stopID)
+		var id StationID
+		if station != nil {
+			id = station.ID
+		}
+		if ok != tt.ok || id != tt.station || direction != tt.direction {
+			t.Errorf("%q: got %v %v %v, want %v %v %v", tt.stopID, id, direction, ok, tt.station, tt.direction, tt.ok)
+		}
+	}
+}
+
+func TestIndexEdgeCases(t *testing.T) {
+	now := time.Now().UTC()
+	b, err := mtatest.LoadFeed(edgeFixtureDir, edgeFixtureFeed, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed := &gtfs.FeedMessage{}
+	if err := proto.Unmarshal(b, feed); err != nil {
+		t.Fatal(err)
+	}
+	c := client(t)
+	c.ingest(edgeFixtureFeed, feed, now)
+
+	var tests = []struct {
+		stopID string
+		want   []string
+	}{
+		// The N train has passed and the R train departs from here.
+		{"R20N", []string{"048000_Q..N"}},
+		{"R17N", []string{"048400_N..N", "048000_Q..N", "048800_R..N"}},
+		{"R16N", []string{"048000_Q..N"}},
+	}
+	for _, tt := range tests {
+		station, d, ok := c.stationByStop(tt.stopID)
+		if !ok {
+			t.Fatalf("%s: station not found", tt.stopID)
+		}
+		var got []string
+		for _, v := range station.Arrivals[d] {
+			got = append(got, v.TripID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.stopID, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshFeed(t *testing.T) {
+	now := time.Now().UTC()
+	srv, c := standIn(t, now)
+	defer srv.Close()
+	fixture := feedFixture(t, 16, now)
+	// trimmed is the fixture without its first trip.
+	feed := &gtfs.FeedMessage{}
+	if err := proto.Unmarshal(fixture, feed); err != nil {
+		t.Fatal(err)
+	}
+	var removed string
+	entities := feed.Entity[:0]
+	for _, e := range feed.Entity {
+		tripID := e.GetTripUpdate().GetTrip().GetTripId()
+		if removed == "" && tripID != "" {
+			removed = tripID
+		}
+		if tripID != removed && e.GetVehicle().GetTrip().GetTripId() != removed {
+			entities = append(entities, e)
+		}
+	}
+	feed.Entity = entities
+	feed.Header.Timestamp = proto.Uint64(uint64(now.Unix() + 30))
+	trimmed, err := proto.Marshal(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name    string
+		feed    []byte
+		status  int
+		err     bool
+		changed bool
+	}{
+		{"first", fixture, 0, false, true},
+		{"unchanged", fixture, 0, false, false},
+		{"failed", fixture, 500, true, false},
+		{"malformed", []byte("not a feed"), 0, true, false},
+		{"trip removed", trimmed, 0, false, true},
+	}
+	for _, tt := range tests {
+		srv.SetFeed(16, tt.feed)
+		srv.FailFeed(16, tt.status)
+		changed, err := c.refreshFeed(context.Background(), 16)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: error got %v, want %v", tt.name, err, tt.err)
+		}
+		if (len(changed) > 0) != tt.changed {
+			t.Errorf("%s: got %d stations changed, want changes %v", tt.name, len(changed), tt.changed)
+		}
+		for _, station := range c.stations {
+			for d, arrivals := range station.Arrivals {
+				if !sort.IsSorted(ByArrivalTime(arrivals)) {
+					t.Errorf("%s: %s %s arrivals not in order", tt.name, station.ID, d)
+				}
+				for _, v := range arrivals {
+					if tt.name == "trip removed" && v.TripID == removed {
+						t.Errorf("%s: %s %s still has trip %s", tt.name, station.ID, d, removed)
+					}
+				}
+			}
+		}
+	}
+	if got, want := srv.Requests(16), len(tests); got != want {
+		t.Errorf("requests got %v, want %v", got, want)
+	}
+}
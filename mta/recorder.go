@@ -0,0 +1,125 @@
+package mta
+
+import (
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// recordQueueSize is the number of feed refreshes buffered for
+	// writing before new ones are dropped.
+	recordQueueSize = 64
+	// recordHorizon is how long the last recorded prediction of a visit
+	// is remembered after its predicted time.
+	recordHorizon = 2 * time.Hour
+	// defaultRecordRetention is how long predictions and arrivals are kept
+	// unless configured otherwise.
+	defaultRecordRetention = 30 * 24 * time.Hour
+	// pruneInterval is how often records past their retention are
+	// deleted.
+	pruneInterval = time.Hour
+)
+
+type recordBatch struct {
+	predictions []*Prediction
+	arrivals    []*ObservedArrival
+}
+
+// recorder writes predictions and observed arrivals to a store off the
+// refresh loop. Only predictions that changed since the last write are
+// stored, and records older than the retention are pruned.
+type recorder struct {
+	store     *Store
+	queue     chan *recordBatch
+	done      chan struct{}
+	last      map[string]time.Time
+	retention time.Duration
+
+	expired time.Time
+	pruned  time.Time
+}
+
+func newRecorder(store *Store, retention time.Duration) *recorder {
+	if retention == 0 {
+		retention = defaultRecordRetention
+	}
+	r := &recorder{
+		store:     store,
+		queue:     make(chan *recordBatch, recordQueueSize),
+		done:      make(chan struct{}),
+		last:      make(map[string]time.Time),
+		retention: retention,
+	}
+	go r.run()
+	return r
+}
+
+// enqueue schedules a batch for writing without blocking.
+func (r *recorder) enqueue(b *recordBatch) {
+	if r == nil || (len(b.predictions) == 0 && len(b.arrivals) == 0) {
+		return
+	}
+	select {
+	case r.queue <- b:
+	default:
+		recordDrops.Inc()
+	}
+}
+
+func (r *recorder) run() {
+	defer close(r.done)
+	for b := range r.queue {
+		predictions := b.predictions[:0]
+		for _, v := range b.predictions {
+			key := string(tripStopKey(v.StartDate, v.TripID, v.StopID))
+			if t, ok := r.last[key]; ok && t.Equal(v.Time) {
+				continue
+			}
+			r.last[key] = v.Time
+			predictions = append(predictions, v)
+		}
+		for _, v := range b.arrivals {
+			delete(r.last, string(tripStopKey(v.StartDate, v.TripID, v.StopID)))
+		}
+		if err := r.store.Write(predictions, b.arrivals); err != nil {
+			log.Print(errors.Wrap(err, "mta: record failed"))
+		}
+		now := time.Now().UTC()
+		r.expire(now)
+		r.prune(now)
+	}
+}
+
+// prune deletes the records past their retention; changes of service
+// status are kept as long as the status history.
+func (r *recorder) prune(now time.Time) {
+	if now.Sub(r.pruned) < pruneInterval {
+		return
+	}
+	r.pruned = now
+	if err := r.store.Prune(now.Add(-r.retention), now.Add(-statusHistoryRetention)); err != nil {
+		log.Print(errors.Wrap(err, "mta: prune failed"))
+	}
+}
+
+func (r *recorder) expire(now time.Time) {
+	if now.Sub(r.expired) < time.Minute {
+		return
+	}
+	r.expired = now
+	cutoff := now.Add(-recordHorizon)
+	for k, t := range r.last {
+		if t.Before(cutoff) {
+			delete(r.last, k)
+		}
+	}
+}
+
+// Close writes the pending batches and closes the store.
+func (r *recorder) Close() error {
+	close(r.queue)
+	<-r.done
+	return r.store.Close()
+}
@@ -0,0 +1,80 @@
+package mta
+
+import (
+	"sort"
+	"strings"
+)
+
+// Route is a subway service, e.g., the Broadway Express.
+type Route struct {
+	ID          string
+	ShortName   string
+	LongName    string
+	Description string
+	Color       string
+	TextColor   string
+	URL         string
+}
+
+// parseRoutes returns the routes from a GTFS routes file, ordered by ID.
+func parseRoutes(path string) ([]*Route, error) {
+	type routeRow struct {
+		ID          string `csv:"route_id"`
+		ShortName   string `csv:"route_short_name"`
+		LongName    string `csv:"route_long_name"`
+		Description string `csv:"route_desc"`
+		URL         string `csv:"route_url"`
+		Color       string `csv:"route_color"`
+		TextColor   string `csv:"route_text_color"`
+	}
+
+	var rows []*routeRow
+	if err := unmarshalFile(path, &rows); err != nil {
+		return nil, err
+	}
+	routes := make([]*Route, 0, len(rows))
+	for _, v := range rows {
+		routes = append(routes, &Route{
+			ID:          v.ID,
+			ShortName:   v.ShortName,
+			LongName:    v.LongName,
+			Description: v.Description,
+			Color:       strings.TrimSpace(v.Color),
+			TextColor:   strings.TrimSpace(v.TextColor),
+			URL:         v.URL,
+		})
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].ID < routes[j].ID })
+	return routes, nil
+}
+
+// GetRoutes returns all routes.
+func (c *Client) GetRoutes() []*Route { return c.routes }
+
+// PublicRouteID returns the route ID riders know a route by: the shuttles
+// are all signed S, and the express variants of a route, e.g., the 6X, are
+// signed as the route.
+func PublicRouteID(routeID string) string {
+	switch {
+	case len(routeID) < 2:
+		return routeID
+	case strings.HasSuffix(routeID, "S"):
+		return "S"
+	case strings.HasSuffix(routeID, "X"):
+		return strings.TrimSuffix(routeID, "X")
+	}
+	return routeID
+}
+
+// servesAny reports whether any of the routes served is one of routes, by
+// public route ID.
+func servesAny(served, routes []string) bool {
+	for _, v := range served {
+		for _, r := range routes {
+			if PublicRouteID(v) == PublicRouteID(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package mta
+
+import "testing"
+
+func TestPublicRouteID(t *testing.T) {
+	var tests = []struct {
+		routeID, expected string
+	}{
+		{"6", "6"},
+		{"6X", "6"},
+		{"FX", "F"},
+		{"GS", "S"},
+		{"FS", "S"},
+		{"H", "H"},
+		{"SI", "SI"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := PublicRouteID(tt.routeID); got != tt.expected {
+			t.Errorf("PublicRouteID(%q) got %q, want %q", tt.routeID, got, tt.expected)
+		}
+	}
+}
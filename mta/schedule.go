@@ -0,0 +1,255 @@
+package mta
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/pkg/errors"
+)
+
+// scheduleWindow is the span on either side of a moment used to derive the
+// scheduled headway.
+const scheduleWindow = 30 * time.Minute
+
+// ScheduleParser returns scheduled stop times from GTFS trip, calendar and
+// stop time files.
+type ScheduleParser struct {
+	TripsPath, CalendarPath, CalendarDatesPath, StopTimesPath string
+}
+
+// schedule indexes the static timetable by public route ID, station and
+// direction.
+type schedule struct {
+	loc      *time.Location
+	services map[string]*service
+	times    map[scheduleKey][]scheduledStop
+}
+
+type scheduleKey struct {
+	RouteID   string
+	StationID StationID
+	Direction Direction
+}
+
+// scheduledStop is a timetabled visit, offset from the start of its service
+// day; offsets may exceed 24 hours for trips running past midnight.
+type scheduledStop struct {
+	service *service
+	offset  time.Duration
+}
+
+// service is a GTFS calendar entry and its exceptions.
+type service struct {
+	weekdays   [7]bool
+	start, end string
+	added      map[string]bool
+	removed    map[string]bool
+}
+
+// active reports whether the service runs on the given service day.
+func (s *service) active(day time.Time) bool {
+	date := day.Format("20060102")
+	if s.added[date] {
+		return true
+	}
+	if s.removed[date] {
+		return false
+	}
+	if s.start == "" || date < s.start || date > s.end {
+		return false
+	}
+	return s.weekdays[day.Weekday()]
+}
+
+// Parse parses the configuration files, resolving stops through the given
+// station map.
+func (p *ScheduleParser) Parse(stations map[string]StationID) (*schedule, error) {
+	type tripRow struct {
+		RouteID   string `csv:"route_id"`
+		ServiceID string `csv:"service_id"`
+		TripID    string `csv:"trip_id"`
+	}
+
+	type calendarRow struct {
+		ServiceID string `csv:"service_id"`
+		Monday    int    `csv:"monday"`
+		Tuesday   int    `csv:"tuesday"`
+		Wednesday int    `csv:"wednesday"`
+		Thursday  int    `csv:"thursday"`
+		Friday    int    `csv:"friday"`
+		Saturday  int    `csv:"saturday"`
+		Sunday    int    `csv:"sunday"`
+		StartDate string `csv:"start_date"`
+		EndDate   string `csv:"end_date"`
+	}
+
+	type calendarDateRow struct {
+		ServiceID     string `csv:"service_id"`
+		Date          string `csv:"date"`
+		ExceptionType int    `csv:"exception_type"`
+	}
+
+	type stopTimeRow struct {
+		TripID        string `csv:"trip_id"`
+		ArrivalTime   string `csv:"arrival_time"`
+		DepartureTime string `csv:"departure_time"`
+		StopID        string `csv:"stop_id"`
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, err
+	}
+
+	var tripRows []*tripRow
+	if err := unmarshalFile(p.TripsPath, &tripRows); err != nil {
+		return nil, err
+	}
+	var calendarRows []*calendarRow
+	if err := unmarshalFile(p.CalendarPath, &calendarRows); err != nil {
+		return nil, err
+	}
+	var calendarDateRows []*calendarDateRow
+	if p.CalendarDatesPath != "" {
+		if err := unmarshalFile(p.CalendarDatesPath, &calendarDateRows); err != nil {
+			return nil, err
+		}
+	}
+	var stopTimeRows []*stopTimeRow
+	if err := unmarshalFile(p.StopTimesPath, &stopTimeRows); err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]*service, len(calendarRows))
+	getService := func(id string) *service {
+		s, ok := services[id]
+		if !ok {
+			s = &service{added: make(map[string]bool), removed: make(map[string]bool)}
+			services[id] = s
+		}
+		return s
+	}
+	for _, v := range calendarRows {
+		s := getService(v.ServiceID)
+		s.weekdays = [7]bool{v.Sunday == 1, v.Monday == 1, v.Tuesday == 1, v.Wednesday == 1, v.Thursday == 1, v.Friday == 1, v.Saturday == 1}
+		s.start, s.end = v.StartDate, v.EndDate
+	}
+	for _, v := range calendarDateRows {
+		s := getService(v.ServiceID)
+		switch v.ExceptionType {
+		case 1:
+			s.added[v.Date] = true
+		case 2:
+			s.removed[v.Date] = true
+		}
+	}
+
+	type trip struct {
+		routeID string
+		service *service
+	}
+	trips := make(map[string]*trip, len(tripRows))
+	for _, v := range tripRows {
+		trips[v.TripID] = &trip{routeID: v.RouteID, service: getService(v.ServiceID)}
+	}
+
+	times := make(map[scheduleKey][]scheduledStop)
+	for _, v := range stopTimeRows {
+		t, ok := trips[v.TripID]
+		if !ok || len(v.StopID) < 2 {
+			continue
+		}
+		stopID, direction := v.StopID[:len(v.StopID)-1], Direction(v.StopID[len(v.StopID)-1:])
+		stationID, ok := stations[stopID]
+		if !ok {
+			continue
+		}
+		clock := v.ArrivalTime
+		if clock == "" {
+			clock = v.DepartureTime
+		}
+		offset, err := parseClock(clock)
+		if err != nil {
+			return nil, errors.Wrapf(err, "mta: trip %s", v.TripID)
+		}
+		key := scheduleKey{RouteID: PublicRouteID(t.routeID), StationID: stationID, Direction: direction}
+		times[key] = append(times[key], scheduledStop{service: t.service, offset: offset})
+	}
+	for _, v := range times {
+		sort.Slice(v, func(i, j int) bool { return v[i].offset < v[j].offset })
+	}
+
+	return &schedule{loc: loc, services: services, times: times}, nil
+}
+
+// Between returns the scheduled visits in [from, to), in order.
+func (s *schedule) Between(key scheduleKey, from, to time.Time) []time.Time {
+	stops := s.times[key]
+	if len(stops) == 0 {
+		return nil
+	}
+	from, to = from.In(s.loc), to.In(s.loc)
+
+	var result []time.Time
+	// Trips on the previous service day may run past midnight.
+	y, m, d := from.Date()
+	for day := time.Date(y, m, d-1, 0, 0, 0, 0, s.loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, v := range stops {
+			t := day.Add(v.offset)
+			if t.Before(from) || !t.Before(to) || !v.service.active(day) {
+				continue
+			}
+			result = append(result, t.UTC())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// Headway returns the median scheduled headway around t, or zero if fewer
+// than two trains are scheduled.
+func (s *schedule) Headway(key scheduleKey, t time.Time) time.Duration {
+	return medianGap(s.Between(key, t.Add(-scheduleWindow), t.Add(scheduleWindow)))
+}
+
+func medianGap(times []time.Time) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+	gaps := make([]time.Duration, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, times[i].Sub(times[i-1]))
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+// parseClock parses a GTFS HH:MM:SS time into an offset from midnight.
+func parseClock(v string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(v), ":")
+	if len(parts) != 3 {
+		return 0, errors.Errorf("invalid time %q", v)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0, errors.Errorf("invalid time %q", v)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+func unmarshalFile(path string, out interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return errors.Wrapf(gocsv.UnmarshalFile(f, out), "mta: parse %s", path)
+}
@@ -1,12 +1,29 @@
 package mta
 
 import (
+	"context"
 	"encoding/xml"
+	"html"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	serviceStatusURL = "http://web.mta.info/status/serviceStatus.txt"
+	// serviceStatusInterval is how often the service status is fetched;
+	// the MTA updates it every minute.
+	serviceStatusInterval = time.Minute
 )
 
+// ErrServiceStatusUnavailable is returned when the service status has not
+// been fetched yet.
+var ErrServiceStatusUnavailable = errors.New("service status unavailable")
+
 // APIServiceResponse is the root node.
 type APIServiceResponse struct {
 	ResponseCode int    `xml:"responsecode"`
@@ -19,57 +36,229 @@ type Subway struct {
 	Lines []SubwayLine `xml:"line"`
 }
 
-// SubwayLine is a line for the subway.
+// SubwayLine is a line for the subway, which groups routes, e.g., NQR.
 type SubwayLine struct {
 	Name   string `xml:"name"`
 	Status string `xml:"status"`
+	// Text is an HTML description of the status.
+	Text string `xml:"text"`
+	Date string `xml:"Date"`
+	Time string `xml:"Time"`
+}
+
+// StatusCategory classifies the service status of a line.
+type StatusCategory string
+
+// Status categories.
+const (
+	StatusGoodService   StatusCategory = "good_service"
+	StatusDelays        StatusCategory = "delays"
+	StatusPlannedWork   StatusCategory = "planned_work"
+	StatusServiceChange StatusCategory = "service_change"
+	StatusSuspended     StatusCategory = "suspended"
+)
+
+// statusCategory returns the category of an MTA status, e.g., PLANNED WORK.
+// Statuses not known are kept in the same form.
+func statusCategory(status string) StatusCategory {
+	return StatusCategory(strings.Replace(strings.ToLower(strings.TrimSpace(status)), " ", "_", -1))
 }
 
+// Status is the service status of a line.
 type Status struct {
-	Line string
-	OK   bool
+	Line     string
+	Routes   []string
+	Category StatusCategory
+	// Description is the text of the status, if other than good service.
+	Description string
+	Posted      *time.Time
 }
 
+// OK returns whether the line has good service.
+func (s *Status) OK() bool {
+	return s.Category == StatusGoodService
+}
+
+// Service is the service status of the subway.
 type Service struct {
 	Updated *time.Time
 	Status  []*Status
 }
 
-const serviceStatusURL = "http://web.mta.info/status/serviceStatus.txt"
-
-// GetServiceStatus returns the current service status.
+// GetServiceStatus returns the service status last fetched, or
+// ErrServiceStatusUnavailable if it has not been fetched.
 func (c *Client) GetServiceStatus() (*Service, error) {
-	req, _ := http.NewRequest("GET", serviceStatusURL, nil)
-	resp, err := c.httpClient().Do(req)
-	defer mustClose(resp.Body)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.service == nil {
+		return nil, ErrServiceStatusUnavailable
+	}
+	return c.service, nil
+}
+
+// pollServiceStatus refreshes the service status until ctx is done, backing
+// off after consecutive failures.
+func (c *Client) pollServiceStatus(ctx context.Context) {
+	var failures int
+	for {
+		err := c.refreshServiceStatus(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		delay := serviceStatusInterval
+		if err != nil {
+			log.Print(err)
+			failures++
+			delay = backoff(failures)
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// refreshServiceStatus fetches the service status, replacing the one last
+// fetched and the planned work it announces, and records and publishes the
+// lines whose status changed.
+func (c *Client) refreshServiceStatus(ctx context.Context) error {
+	service, class, err := c.fetchServiceStatus(ctx)
+	serviceStatusFetches.Inc(class)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	work := c.statusPlannedWork(service)
+	c.mtx.Lock()
+	c.service = service
+	c.statusWork = work
+	c.mtx.Unlock()
+	changes := c.history.observe(service, time.Now().UTC())
+	c.recordStatusChanges(changes)
+	c.publishStatus(changes)
+	return nil
+}
+
+func (c *Client) fetchServiceStatus(ctx context.Context) (*Service, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
+	req, _ := http.NewRequest("GET", c.serviceStatusURL, nil)
+	resp, err := c.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fetchRequest, errors.Wrap(err, "mta: service status request failed")
+	}
+	defer mustClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fetchStatus, errors.Errorf("mta: service status returned %s", resp.Status)
 	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, fetchRead, errors.Wrap(err, "mta: service status read failed")
 	}
+	service, err := c.parseServiceStatus(body)
+	if err != nil {
+		return nil, fetchDecode, err
+	}
+	return service, fetchOK, nil
+}
 
+// parseServiceStatus parses the MTA's service status document.
+func (c *Client) parseServiceStatus(body []byte) (*Service, error) {
 	var service *APIServiceResponse
-	err = xml.Unmarshal(body, &service)
-	if err != nil {
-		return nil, err
+	if err := xml.Unmarshal(body, &service); err != nil {
+		return nil, errors.Wrap(err, "mta: service status unmarshal failed")
 	}
 
-	loc, _ := time.LoadLocation("America/New_York")
-	updated, err := time.ParseInLocation("2/1/2006 3:04:05 PM", service.Updated, loc)
+	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
 		return nil, err
 	}
+	updated, err := time.ParseInLocation("1/2/2006 3:04:05 PM", service.Updated, loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "mta: invalid service status timestamp")
+	}
 	updated = updated.UTC()
 
 	status := make([]*Status, 0, len(service.Subway.Lines))
 	for _, line := range service.Subway.Lines {
-		status = append(status, &Status{
-			Line: line.Name,
-			OK:   line.Status == "GOOD SERVICE",
-		})
+		s := &Status{
+			Line:        line.Name,
+			Routes:      c.lineRoutes(line.Name),
+			Category:    statusCategory(line.Status),
+			Description: plainText(line.Text),
+		}
+		date := strings.TrimSpace(line.Date) + " " + strings.TrimSpace(line.Time)
+		if posted, err := time.ParseInLocation("01/02/2006 3:04PM", date, loc); err == nil {
+			posted = posted.UTC()
+			s.Posted = &posted
+		}
+		status = append(status, s)
 	}
-
 	return &Service{Updated: &updated, Status: status}, nil
 }
+
+// lineRoutes returns the routes of a line of the service status, which
+// names the routes it groups, e.g., NQR, save for the shuttles and the
+// Staten Island Railway.
+func (c *Client) lineRoutes(name string) []string {
+	switch name {
+	case "S":
+		return []string{"GS", "FS", "H"}
+	case "SIR":
+		return []string{"SI"}
+	}
+	routes := make([]string, 0, len(name))
+	for _, r := range name {
+		id := string(r)
+		routes = append(routes, id)
+		// Express variants, e.g., 6X, share the status of the route.
+		for _, route := range c.routes {
+			if route.ID == id+"X" {
+				routes = append(routes, route.ID)
+			}
+		}
+	}
+	return routes
+}
+
+// plainText returns the text of an HTML fragment, with a line per
+// paragraph or line break.
+func plainText(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		j := strings.IndexByte(s, '>')
+		if j < 0 {
+			break
+		}
+		tag := strings.ToLower(strings.Trim(s[1:j], "/ "))
+		if k := strings.IndexAny(tag, " \t\n"); k >= 0 {
+			tag = tag[:k]
+		}
+		switch tag {
+		case "br", "p", "div", "li", "tr", "h1", "h2", "h3", "h4":
+			b.WriteByte('\n')
+		}
+		s = s[j+1:]
+	}
+
+	// Fields splits on non-breaking spaces too.
+	var lines []string
+	for _, line := range strings.Split(html.UnescapeString(b.String()), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
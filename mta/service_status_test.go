@@ -0,0 +1,108 @@
+package mta
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetServiceStatus(t *testing.T) {
+	srv, c := standIn(t, time.Now())
+	defer srv.Close()
+
+	if _, err := c.GetServiceStatus(); err != ErrServiceStatusUnavailable {
+		t.Errorf("before refresh: got %v, want %v", err, ErrServiceStatusUnavailable)
+	}
+	if err := c.refreshServiceStatus(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	service, err := c.GetServiceStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := service.Updated, time.Date(2018, 6, 13, 12, 2, 0, 0, time.UTC); got == nil || !got.Equal(want) {
+		t.Errorf("Updated got %v, want %v", got, want)
+	}
+	want := map[string]StatusCategory{
+		"123": StatusGoodService, "456": StatusDelays, "7": StatusGoodService, "ACE": StatusPlannedWork,
+		"BDFM": StatusServiceChange, "G": StatusGoodService, "JZ": StatusGoodService, "L": StatusSuspended,
+		"NQR": StatusGoodService, "S": StatusGoodService, "SIR": StatusGoodService,
+	}
+	if len(service.Status) != len(want) {
+		t.Errorf("len(Status) got %v, want %v", len(service.Status), len(want))
+	}
+	for _, v := range service.Status {
+		if category, found := want[v.Line]; !found || v.Category != category {
+			t.Errorf("%s: Category got %v, want %v", v.Line, v.Category, category)
+		}
+		if got, want := v.OK(), v.Category == StatusGoodService; got != want {
+			t.Errorf("%s: OK got %v, want %v", v.Line, got, want)
+		}
+		if got, want := v.Description != "", !v.OK(); got != want {
+			t.Errorf("%s: Description %q", v.Line, v.Description)
+		}
+	}
+
+	delays := service.Status[1]
+	if got, want := delays.Description, "Delays\nPosted: 06/13/2018 7:48AM\nNorthbound [4], [5] and [6] trains are running with delays while we address a signal problem at 125 St."; got != want {
+		t.Errorf("Description got %q, want %q", got, want)
+	}
+	if got, want := delays.Posted, time.Date(2018, 6, 13, 11, 48, 0, 0, time.UTC); got == nil || !got.Equal(want) {
+		t.Errorf("Posted got %v, want %v", got, want)
+	}
+	if got, want := delays.Routes, []string{"4", "5", "5X", "6", "6X"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Routes got %v, want %v", got, want)
+	}
+
+	// A failed refresh keeps the status last fetched.
+	for _, fail := range []func(){
+		func() { srv.SetServiceStatus([]byte("<service><timestamp>")) },
+		func() { srv.FailServiceStatus(503) },
+	} {
+		fail()
+		if err := c.refreshServiceStatus(context.Background()); err == nil {
+			t.Errorf("got no error")
+		}
+		if got, _ := c.GetServiceStatus(); got != service {
+			t.Errorf("got %v, want %v", got, service)
+		}
+	}
+}
+
+func TestLineRoutes(t *testing.T) {
+	c := client(t)
+	var tests = []struct {
+		line string
+		want []string
+	}{
+		{"NQR", []string{"N", "Q", "R"}},
+		{"123", []string{"1", "2", "3"}},
+		{"7", []string{"7", "7X"}},
+		{"BDFM", []string{"B", "D", "F", "FX", "M"}},
+		{"S", []string{"GS", "FS", "H"}},
+		{"SIR", []string{"SI"}},
+	}
+	for _, tt := range tests {
+		if got := c.lineRoutes(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	var tests = []struct {
+		html, want string
+	}{
+		{"", ""},
+		{"Good service", "Good service"},
+		{`<span class="TitleDelay">Delays</span><br/><P>[A] trains&nbsp;are <STRONG>delayed</STRONG>.</P>`, "Delays\n[A] trains are delayed."},
+		{"<p>One</p>\n\n<p>Two &amp; three</p>", "One\nTwo & three"},
+		{"Unclosed <b", "Unclosed"},
+	}
+	for _, tt := range tests {
+		if got := plainText(tt.html); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.html, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package mta
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// statusHistoryRetention is how long changes of service status are kept
+// in memory, save for the last change of each line.
+const statusHistoryRetention = 31 * 24 * time.Hour
+
+// StatusChange is a line entering a status category.
+type StatusChange struct {
+	Line     string
+	Routes   []string
+	Category StatusCategory
+	// Previous is the category of the line before, or empty for the first
+	// status observed.
+	Previous    StatusCategory `json:",omitempty"`
+	Time        time.Time
+	Description string `json:",omitempty"`
+}
+
+// StatusStats is how long a line spent in each status category on a day
+// in New York.
+type StatusStats struct {
+	Line      string
+	Date      string
+	Durations map[StatusCategory]time.Duration
+}
+
+// statusHistory tracks the changes of service status of each line.
+type statusHistory struct {
+	mtx sync.Mutex
+	// changes are ordered by time.
+	changes []*StatusChange
+	current map[string]*StatusChange
+}
+
+func newStatusHistory() *statusHistory {
+	return &statusHistory{current: make(map[string]*StatusChange)}
+}
+
+// load adds changes recorded before, e.g., by a previous process.
+func (h *statusHistory) load(changes []*StatusChange, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for _, v := range changes {
+		h.add(v)
+	}
+	h.expire(now)
+}
+
+// observe records the lines of the service status whose category changed,
+// returning the changes. A line is taken to have changed when its status
+// was posted, if that is known, or else when the status was updated.
+func (h *statusHistory) observe(service *Service, now time.Time) []*StatusChange {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var result []*StatusChange
+	for _, s := range service.Status {
+		prev := h.current[s.Line]
+		if prev != nil && prev.Category == s.Category {
+			continue
+		}
+		at := *service.Updated
+		if s.Posted != nil && s.Posted.Before(at) && (prev == nil || s.Posted.After(prev.Time)) {
+			at = *s.Posted
+		}
+		change := &StatusChange{
+			Line:        s.Line,
+			Routes:      s.Routes,
+			Category:    s.Category,
+			Time:        at,
+			Description: s.Description,
+		}
+		if prev != nil {
+			change.Previous = prev.Category
+		}
+		h.add(change)
+		result = append(result, change)
+	}
+	h.expire(now)
+	return result
+}
+
+// add inserts a change in order. The caller must hold h.mtx.
+func (h *statusHistory) add(v *StatusChange) {
+	i := sort.Search(len(h.changes), func(i int) bool { return h.changes[i].Time.After(v.Time) })
+	h.changes = append(h.changes, nil)
+	copy(h.changes[i+1:], h.changes[i:])
+	h.changes[i] = v
+	if cur, ok := h.current[v.Line]; !ok || !v.Time.Before(cur.Time) {
+		h.current[v.Line] = v
+	}
+}
+
+// expire drops changes past retention other than the last of each line.
+// The caller must hold h.mtx.
+func (h *statusHistory) expire(now time.Time) {
+	cutoff := now.Add(-statusHistoryRetention)
+	kept := h.changes[:0]
+	for _, v := range h.changes {
+		if v.Time.Before(cutoff) && h.current[v.Line] != v {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	for i := len(kept); i < len(h.changes); i++ {
+		h.changes[i] = nil
+	}
+	h.changes = kept
+}
+
+// recordStatusChanges persists changes of service status if the client
+// records.
+func (c *Client) recordStatusChanges(changes []*StatusChange) {
+	if c.recorder == nil || len(changes) == 0 {
+		return
+	}
+	if err := c.recorder.store.WriteStatusChanges(changes); err != nil {
+		log.Print(errors.Wrap(err, "mta: record status failed"))
+	}
+}
+
+// GetStatusHistory returns the changes of service status since the given
+// time of the lines serving a route, or of all lines if routeID is empty,
+// in the order they occurred. Changes are kept for a month. Routes match by
+// public route ID, e.g., S matches the lines of the shuttles.
+func (c *Client) GetStatusHistory(routeID string, since time.Time) []*StatusChange {
+	c.history.mtx.Lock()
+	defer c.history.mtx.Unlock()
+
+	var result []*StatusChange
+	for _, v := range c.history.changes {
+		if v.Time.Before(since) || routeID != "" && !servesAny(v.Routes, []string{routeID}) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// GetStatusStats returns how long the lines serving any of the routes, or
+// all lines if none are given, spent in each status category on each of
+// the last days in New York, including today, ordered by line and date.
+func (c *Client) GetStatusStats(routes []string, days int, now time.Time) ([]*StatusStats, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, err
+	}
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day()-days+1, 0, 0, 0, 0, loc)
+
+	c.history.mtx.Lock()
+	byLine := make(map[string][]*StatusChange)
+	for _, v := range c.history.changes {
+		if len(routes) > 0 && !servesAny(v.Routes, routes) {
+			continue
+		}
+		byLine[v.Line] = append(byLine[v.Line], v)
+	}
+	c.history.mtx.Unlock()
+
+	lines := make([]string, 0, len(byLine))
+	for line := range byLine {
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+
+	var result []*StatusStats
+	for _, line := range lines {
+		changes := byLine[line]
+		var stats []*StatusStats
+		byDate := make(map[string]*StatusStats)
+		for i, v := range changes {
+			from, to := v.Time, now
+			if i+1 < len(changes) {
+				to = changes[i+1].Time
+			}
+			if from.Before(start) {
+				from = start
+			}
+			// Each day the status spans is counted separately.
+			for from.Before(to) {
+				day := from.In(loc)
+				next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+				end := to
+				if next.Before(end) {
+					end = next
+				}
+				date := day.Format("2006-01-02")
+				s, ok := byDate[date]
+				if !ok {
+					s = &StatusStats{Line: line, Date: date, Durations: make(map[StatusCategory]time.Duration)}
+					byDate[date] = s
+					stats = append(stats, s)
+				}
+				s.Durations[v.Category] += end.Sub(from)
+				from = end
+			}
+		}
+		result = append(result, stats...)
+	}
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package mta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// serviceAt returns a service status updated at the given time with lines
+// in the given categories.
+func serviceAt(updated time.Time, categories map[string]StatusCategory) *Service {
+	service := &Service{Updated: &updated}
+	for _, line := range []string{"L", "NQR", "S"} {
+		if category, ok := categories[line]; ok {
+			routes := []string{line}
+			switch line {
+			case "NQR":
+				routes = []string{"N", "Q", "R"}
+			case "S":
+				routes = []string{"GS", "FS", "H"}
+			}
+			service.Status = append(service.Status, &Status{Line: line, Routes: routes, Category: category})
+		}
+	}
+	return service
+}
+
+func TestStatusHistory(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 PM in New York, two hours before midnight.
+	start := time.Date(2018, 6, 13, 22, 0, 0, 0, loc).UTC()
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	c := client(t)
+	observe := func(minutes int, categories map[string]StatusCategory) {
+		c.history.observe(serviceAt(at(minutes), categories), at(minutes))
+	}
+	observe(0, map[string]StatusCategory{"L": StatusGoodService, "NQR": StatusGoodService})
+	observe(1, map[string]StatusCategory{"L": StatusGoodService, "NQR": StatusGoodService})
+	delayed := serviceAt(at(31), map[string]StatusCategory{"L": StatusGoodService, "NQR": StatusDelays})
+	// The delays were posted before the status was fetched.
+	posted := at(30)
+	delayed.Status[1].Posted = &posted
+	c.history.observe(delayed, at(31))
+	observe(150, map[string]StatusCategory{"L": StatusGoodService, "NQR": StatusGoodService})
+
+	var got []string
+	for _, v := range c.GetStatusHistory("", start) {
+		got = append(got, v.Line+":"+string(v.Previous)+">"+string(v.Category)+"@"+v.Time.Sub(start).String())
+	}
+	want := []string{
+		"L:>good_service@0s",
+		"NQR:>good_service@0s",
+		"NQR:good_service>delays@30m0s",
+		"NQR:delays>good_service@2h30m0s",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("history got %v, want %v", got, want)
+	}
+	if got, want := len(c.GetStatusHistory("Q", at(1))), 2; got != want {
+		t.Errorf("Q since 1m: got %v, want %v", got, want)
+	}
+	if got, want := len(c.GetStatusHistory("L", at(1))), 0; got != want {
+		t.Errorf("L since 1m: got %v, want %v", got, want)
+	}
+
+	stats, err := c.GetStatusStats([]string{"Q"}, 2, at(180))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, s := range stats {
+		got = append(got, s.Line+" "+s.Date+" "+s.Durations[StatusGoodService].String()+" "+s.Durations[StatusDelays].String())
+	}
+	// The delays span midnight in New York.
+	want = []string{
+		"NQR 2018-06-13 30m0s 1h30m0s",
+		"NQR 2018-06-14 30m0s 30m0s",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stats got %v, want %v", got, want)
+	}
+	if stats, _ := c.GetStatusStats(nil, 1, at(180)); len(stats) != 2 {
+		t.Errorf("today: got %v stats, want 2", len(stats))
+	}
+
+	// Routes match by public route ID: the shuttles are all S.
+	observe(200, map[string]StatusCategory{"S": StatusDelays})
+	for _, routeID := range []string{"S", "GS"} {
+		if got, want := len(c.GetStatusHistory(routeID, at(190))), 1; got != want {
+			t.Errorf("%s since 190m: got %v, want %v", routeID, got, want)
+		}
+	}
+	if stats, _ := c.GetStatusStats([]string{"S"}, 1, at(210)); len(stats) != 1 || stats[0].Line != "S" {
+		t.Errorf("S stats got %v, want the S line", stats)
+	}
+}
+
+func TestStatusHistoryRecorded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "record.db")
+
+	now := time.Now().UTC().Truncate(time.Second)
+	store, err := OpenStore(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := []*StatusChange{
+		// Both are past retention, but only the first is the last change
+		// of its line, which is kept.
+		{Line: "L", Routes: []string{"L"}, Category: StatusGoodService, Time: now.Add(-40 * 24 * time.Hour)},
+		{Line: "NQR", Routes: []string{"N", "Q", "R"}, Category: StatusGoodService, Time: now.Add(-40 * 24 * time.Hour)},
+		{Line: "NQR", Routes: []string{"N", "Q", "R"}, Category: StatusDelays, Previous: StatusGoodService, Time: now.Add(-time.Hour)},
+	}
+	if err := store.WriteStatusChanges(changes); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient(&ClientConfig{
+		StopsFilePath:     "./testdata/gtfs/stops.txt",
+		TransfersFilePath: "./testdata/gtfs/transfers.txt",
+		RecordPath:        path,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := c.GetStatusHistory("", time.Time{})
+	if len(got) != 2 {
+		t.Fatalf("got %v changes, want 2", len(got))
+	}
+	if !reflect.DeepEqual(got[0], changes[0]) || !reflect.DeepEqual(got[1], changes[2]) {
+		t.Errorf("got %+v, want %+v and %+v", got, changes[0], changes[2])
+	}
+
+	// A line in the same status is not recorded again.
+	recorded := c.history.observe(serviceAt(now, map[string]StatusCategory{"L": StatusGoodService, "NQR": StatusGoodService}), now)
+	c.recordStatusChanges(recorded)
+	if len(recorded) != 1 || recorded[0].Previous != StatusDelays {
+		t.Errorf("got %+v, want NQR back to good service", recorded)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = OpenStore(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	stored, err := store.StatusChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(stored), 4; got != want {
+		t.Errorf("stored got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,224 @@
+package mta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	predictionsBucket = []byte("predictions")
+	arrivalsBucket    = []byte("arrivals")
+	statusBucket      = []byte("status")
+)
+
+// Prediction is the predicted arrival of a trip at a stop as of a feed
+// refresh.
+type Prediction struct {
+	TripID    string
+	StartDate string
+	RouteID   string
+	StopID    string
+	Time      time.Time
+	Observed  time.Time
+}
+
+// ObservedArrival is the inferred arrival of a trip at a stop.
+type ObservedArrival struct {
+	TripID    string
+	StartDate string
+	RouteID   string
+	StopID    string
+	Time      time.Time
+}
+
+// Store persists predictions and observed arrivals in an embedded database.
+type Store struct{ db *bolt.DB }
+
+// OpenStore opens or creates the database at path.
+func OpenStore(path string, readOnly bool) (*Store, error) {
+	db, err := bolt.Open(path, os.FileMode(0644), &bolt.Options{Timeout: time.Second, ReadOnly: readOnly})
+	if err != nil {
+		return nil, err
+	}
+	if !readOnly {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{predictionsBucket, arrivalsBucket, statusBucket} {
+				if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error { return s.db.Close() }
+
+// tripStopKey identifies a trip's visit to a stop; trip IDs repeat daily,
+// so they are qualified by the start date, which leads the key so that
+// records are ordered by day.
+func tripStopKey(startDate, tripID, stopID string) []byte {
+	return []byte(startDate + "\x00" + tripID + "\x00" + stopID + "\x00")
+}
+
+// startDateSlack is how long before an arrival its trip may have started
+// on the calendar: start dates are in New York, and trips run past
+// midnight.
+const startDateSlack = 48 * time.Hour
+
+// startDateKey returns the key of the first trip that may have started
+// since t, less slack.
+func startDateKey(t time.Time, slack time.Duration) []byte {
+	return []byte(t.UTC().Add(-slack).Format("20060102"))
+}
+
+// Write stores a batch of predictions and observed arrivals.
+func (s *Store) Write(predictions []*Prediction, arrivals []*ObservedArrival) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(predictionsBucket)
+		for _, v := range predictions {
+			key := tripStopKey(v.StartDate, v.TripID, v.StopID)
+			var ts [8]byte
+			binary.BigEndian.PutUint64(ts[:], uint64(v.Observed.Unix()))
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(append(key, ts[:]...), value); err != nil {
+				return err
+			}
+		}
+		b = tx.Bucket(arrivalsBucket)
+		for _, v := range arrivals {
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(tripStopKey(v.StartDate, v.TripID, v.StopID), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Arrivals calls fn for each observed arrival of the trips started in the
+// two days before since or later, along with the predictions recorded for
+// it, in the order they were observed. Arrivals before since may be
+// included.
+func (s *Store) Arrivals(since time.Time, fn func(*ObservedArrival, []*Prediction) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		predictions := tx.Bucket(predictionsBucket)
+		arrivals := tx.Bucket(arrivalsBucket)
+		if predictions == nil || arrivals == nil {
+			return nil
+		}
+		pc := predictions.Cursor()
+		ac := arrivals.Cursor()
+		for k, v := ac.Seek(startDateKey(since, startDateSlack)); k != nil; k, v = ac.Next() {
+			var arrival ObservedArrival
+			if err := json.Unmarshal(v, &arrival); err != nil {
+				return err
+			}
+			var pp []*Prediction
+			for pk, pv := pc.Seek(k); pk != nil && bytes.HasPrefix(pk, k); pk, pv = pc.Next() {
+				var p Prediction
+				if err := json.Unmarshal(pv, &p); err != nil {
+					return err
+				}
+				pp = append(pp, &p)
+			}
+			if err := fn(&arrival, pp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Prune deletes the predictions and arrivals of the trips that started
+// two days or more before the given time, and the changes of service status
+// before statusBefore.
+func (s *Store) Prune(before, statusBefore time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{predictionsBucket, arrivalsBucket} {
+			if err := deleteBefore(tx.Bucket(name), startDateKey(before, startDateSlack)); err != nil {
+				return err
+			}
+		}
+		var key [8]byte
+		binary.BigEndian.PutUint64(key[:], uint64(statusBefore.UnixNano()))
+		return deleteBefore(tx.Bucket(statusBucket), key[:])
+	})
+}
+
+// deleteBefore deletes the keys of a bucket ordered before bound. The keys
+// are collected first, as deleting under a cursor may skip the next key.
+func deleteBefore(b *bolt.Bucket, bound []byte) error {
+	if b == nil {
+		return nil
+	}
+	var keys [][]byte
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k, bound) < 0; k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStatusChanges stores changes of service status.
+func (s *Store) WriteStatusChanges(changes []*StatusChange) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(statusBucket)
+		for _, v := range changes {
+			// Changes are keyed by time, then line.
+			key := make([]byte, 8, 8+len(v.Line))
+			binary.BigEndian.PutUint64(key, uint64(v.Time.UnixNano()))
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(append(key, v.Line...), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StatusChanges returns the changes of service status stored, in the order
+// they occurred.
+func (s *Store) StatusChanges() ([]*StatusChange, error) {
+	var result []*StatusChange
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(statusBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var change StatusChange
+			if err := json.Unmarshal(v, &change); err != nil {
+				return err
+			}
+			result = append(result, &change)
+			return nil
+		})
+	})
+	return result, err
+}
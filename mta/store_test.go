@@ -0,0 +1,163 @@
+package mta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "record.db")
+
+	store, err := OpenStore(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRecorder(store, 0)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	startDate := now.Format("20060102")
+	predict := func(tripID, stopID string, at, observed int) *Prediction {
+		return &Prediction{
+			TripID:    tripID,
+			StartDate: startDate,
+			RouteID:   "Q",
+			StopID:    stopID,
+			Time:      now.Add(time.Duration(at) * time.Minute),
+			Observed:  now.Add(time.Duration(observed) * time.Minute),
+		}
+	}
+	r.enqueue(&recordBatch{predictions: []*Prediction{predict("a", "R20N", 5, 0), predict("b", "R20N", 9, 0)}})
+	// Unchanged predictions are not recorded again.
+	r.enqueue(&recordBatch{predictions: []*Prediction{predict("a", "R20N", 5, 1), predict("b", "R20N", 10, 1)}})
+	r.enqueue(&recordBatch{
+		predictions: []*Prediction{predict("a", "R20N", 6, 2)},
+		arrivals: []*ObservedArrival{
+			{TripID: "a", StartDate: startDate, RouteID: "Q", StopID: "R20N", Time: now.Add(6 * time.Minute)},
+		},
+	})
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = OpenStore(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	var arrivals []*ObservedArrival
+	var predictions []*Prediction
+	err = store.Arrivals(now, func(a *ObservedArrival, p []*Prediction) error {
+		arrivals = append(arrivals, a)
+		predictions = append(predictions, p...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arrivals) != 1 || arrivals[0].TripID != "a" {
+		t.Fatalf("arrivals got %v, want [a]", arrivals)
+	}
+	if len(predictions) != 2 {
+		t.Fatalf("predictions got %v, want %v", len(predictions), 2)
+	}
+	for i, want := range []int{5, 6} {
+		if got := predictions[i].Time; !got.Equal(now.Add(time.Duration(want) * time.Minute)) {
+			t.Errorf("predictions[%d] got %v, want +%vm", i, got, want)
+		}
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := OpenStore(filepath.Join(dir, "record.db"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var (
+		predictions []*Prediction
+		arrivals    []*ObservedArrival
+	)
+	for _, days := range []int{10, 3, 0} {
+		at := now.AddDate(0, 0, -days)
+		id := strconv.Itoa(days)
+		predictions = append(predictions, &Prediction{TripID: id, StartDate: at.Format("20060102"), StopID: "R20N", Time: at, Observed: at})
+		arrivals = append(arrivals, &ObservedArrival{TripID: id, StartDate: at.Format("20060102"), StopID: "R20N", Time: at})
+	}
+	if err := store.Write(predictions, arrivals); err != nil {
+		t.Fatal(err)
+	}
+	err = store.WriteStatusChanges([]*StatusChange{
+		{Line: "L", Time: now.AddDate(0, 0, -40)},
+		{Line: "L", Time: now.AddDate(0, 0, -1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Prune(now.AddDate(0, 0, -1), now.AddDate(0, 0, -31)); err != nil {
+		t.Fatal(err)
+	}
+	var trips []string
+	err = store.Arrivals(now.AddDate(0, 0, -30), func(a *ObservedArrival, p []*Prediction) error {
+		if len(p) != 1 {
+			t.Errorf("%s predictions got %v, want %v", a.TripID, len(p), 1)
+		}
+		trips = append(trips, a.TripID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Trips started up to two days before the cutoff are kept.
+	if want := []string{"3", "0"}; !reflect.DeepEqual(trips, want) {
+		t.Errorf("trips got %v, want %v", trips, want)
+	}
+	changes, err := store.StatusChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || !changes[0].Time.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf("status changes got %v, want the last", changes)
+	}
+}
+
+func TestTrackerArrivals(t *testing.T) {
+	c := client(t)
+	now := time.Now().UTC()
+	c.tracker.observe(c, 1, []*tripObservation{
+		{TripID: "a", RouteID: "Q", StopID: "R20N", Arrival: now.Add(time.Minute)},
+		{TripID: "b", RouteID: "Q", StopID: "Q01N", Arrival: now.Add(-time.Minute)},
+	}, now)
+
+	later := now.Add(2 * time.Minute)
+	arrivals := c.tracker.observe(c, 1, []*tripObservation{
+		{TripID: "a", RouteID: "Q", StopID: "R19N", Arrival: later.Add(time.Minute)},
+	}, later)
+	if len(arrivals) != 2 {
+		t.Fatalf("arrivals got %v, want %v", len(arrivals), 2)
+	}
+	for _, v := range arrivals {
+		want := map[string]time.Time{"a": now.Add(time.Minute), "b": now.Add(-time.Minute)}[v.TripID]
+		if !v.Time.Equal(want) {
+			t.Errorf("%s arrival got %v, want %v", v.TripID, v.Time, want)
+		}
+	}
+}
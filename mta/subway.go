@@ -24,8 +24,9 @@ type Station struct {
 	ID          StationID
 	Name        string
 	Coordinates *Coordinates
-	Arrivals    map[Direction][]*Arrival
-	Updated     *time.Time
+	// Arrivals is replaced, never modified, when a feed is refreshed.
+	Arrivals map[Direction][]*Arrival
+	Updated  *time.Time
 }
 
 // Arrival is a truncation of the GTFS spec.
@@ -33,6 +34,29 @@ type Arrival struct {
 	TripID  string
 	RouteID string
 	Time    *time.Time
+	// StartDate is the service date of the trip, e.g., 20180601.
+	StartDate string
+	// FeedID is the feed that predicted the arrival.
+	FeedID int
+	// Updated is when the feed published the prediction.
+	Updated *time.Time
+	// StaleAt is when the prediction becomes stale unless the feed updates
+	// it; it is never stale when zero.
+	StaleAt time.Time
+}
+
+// Age returns how long ago the feed published the prediction.
+func (a *Arrival) Age(now time.Time) time.Duration {
+	if a.Updated == nil {
+		return 0
+	}
+	return now.Sub(*a.Updated)
+}
+
+// Stale reports whether the feed that predicted the arrival has not
+// updated it for longer than the client allows.
+func (a *Arrival) Stale(now time.Time) bool {
+	return !a.StaleAt.IsZero() && now.After(a.StaleAt)
 }
 
 // Coordinates represents a point on the Earth's surface.
@@ -58,16 +82,51 @@ func (s ByArrivalTime) Less(i, j int) bool {
 	return s[i].Time.Before(t)
 }
 
-func cleanupArrivals(s []*Arrival) []*Arrival {
-	now := time.Now().UTC()
-	tripIDs := make(map[string]struct{})
+// sameArrivals reports whether two lists of arrivals predict the same trips
+// at the same times.
+func sameArrivals(a, b []*Arrival) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].TripID != b[i].TripID || a[i].RouteID != b[i].RouteID || !a[i].Time.Equal(*b[i].Time) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStationArrivals reports whether the arrivals of a station are the
+// same in every direction.
+func sameStationArrivals(a, b map[Direction][]*Arrival) bool {
+	for d, s := range a {
+		if !sameArrivals(s, b[d]) {
+			return false
+		}
+	}
+	for d, s := range b {
+		if _, ok := a[d]; !ok && len(s) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanupArrivals drops, in place, arrivals that have passed by now and
+// all but the first upcoming arrival of a trip. The lists of a station are
+// short, so the arrivals kept are scanned rather than kept in a set.
+func cleanupArrivals(s []*Arrival, now time.Time) []*Arrival {
 	y := s[:0]
 	for _, n := range s {
-		if _, ok := tripIDs[n.TripID]; !ok {
-			if n.Time.After(now) {
-				y = append(y, n)
+		seen := false
+		for _, m := range y {
+			if m.TripID == n.TripID {
+				seen = true
+				break
 			}
-			tripIDs[n.TripID] = struct{}{}
+		}
+		if !seen && n.Time.After(now) {
+			y = append(y, n)
 		}
 	}
 	return y
@@ -78,32 +137,38 @@ const maxStations = 5
 // GetStations returns all stations.
 func (c *Client) GetStations() Stations { return c.stations }
 
-var errStationNotFound = errors.New("station not found")
+// ErrStationNotFound is returned for unknown station and stop IDs.
+var ErrStationNotFound = errors.New("station not found")
 
 // GetStationByStopID returns the station, i.e., an aggregation of GTFS
 // stops, for the GTFS stop id.
 func (c *Client) GetStationByStopID(id string) (*Station, error) {
 	stationID, ok := c.stops[id]
 	if !ok {
-		return nil, errStationNotFound
+		return nil, ErrStationNotFound
 	}
 	station, ok := c.stations[stationID]
 	if !ok {
-		return nil, errStationNotFound
+		return nil, ErrStationNotFound
 	}
 	return station, nil
 }
 
-// GetStation returns a station
+// GetStation returns a snapshot of a station: its arrivals are those as of
+// the call, and are safe to read while feeds are refreshed.
 func (c *Client) GetStation(id StationID) (*Station, error) {
 	s, ok := c.stations[id]
 	if !ok {
-		return nil, errStationNotFound
+		return nil, ErrStationNotFound
 	}
-	return s, nil
+	c.mtx.Lock()
+	v := *s
+	c.mtx.Unlock()
+	return &v, nil
 }
 
-// GetClosestStations returns the closest stations for the given coordinates.
+// GetClosestStations returns snapshots of the closest stations for the
+// given coordinates.
 func (c *Client) GetClosestStations(v *Coordinates, numStations int) []*Station {
 	if numStations >= maxStations {
 		numStations = maxStations
@@ -0,0 +1,64 @@
+package mta
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCleanupArrivals(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	var tests = []struct {
+		name     string
+		arrivals []*Arrival
+		want     []string
+	}{
+		{"empty", nil, nil},
+		{"upcoming", []*Arrival{{TripID: "a", Time: at(1)}, {TripID: "b", Time: at(2)}}, []string{"a", "b"}},
+		{"passed", []*Arrival{{TripID: "a", Time: at(-1)}, {TripID: "b", Time: at(2)}}, []string{"b"}},
+		{"duplicate", []*Arrival{{TripID: "a", Time: at(1)}, {TripID: "a", Time: at(2)}, {TripID: "b", Time: at(3)}}, []string{"a", "b"}},
+		// A loop trip arrives again once its first arrival has passed.
+		{"loop", []*Arrival{{TripID: "a", Time: at(-1)}, {TripID: "a", Time: at(30)}}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, v := range cleanupArrivals(tt.arrivals, now) {
+			got = append(got, v.TripID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestByArrivalTime(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	var tests = []struct {
+		name     string
+		arrivals []*Arrival
+		want     []string
+	}{
+		{"sorted", []*Arrival{{TripID: "a", Time: at(1)}, {TripID: "b", Time: at(2)}}, []string{"a", "b"}},
+		{"reversed", []*Arrival{{TripID: "b", Time: at(2)}, {TripID: "a", Time: at(1)}}, []string{"a", "b"}},
+		{"ties", []*Arrival{{TripID: "a", Time: at(1)}, {TripID: "b", Time: at(1)}, {TripID: "c", Time: at(0)}}, []string{"c", "a", "b"}},
+	}
+	for _, tt := range tests {
+		sort.Stable(ByArrivalTime(tt.arrivals))
+		var got []string
+		for _, v := range tt.arrivals {
+			got = append(got, v.TripID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
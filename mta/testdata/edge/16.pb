@@ -0,0 +1,15 @@
+
+
+1.0s
+048000_Q..Nd
+
+048000_Q..N20180601*Q"R16N"R20N"R17N[
+048400_N..NL
+
+048400_N..N20180601*N"R20N"R17N
+048800_R..Ns
+
+048800_R..N20180601*R"R20N"XXXN"R17"R17NC
+048000_Q..N4
+
+048000_Q..N20180601*Q"R20N
\ No newline at end of file
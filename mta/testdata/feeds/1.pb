@@ -0,0 +1,1267 @@
+
+
+1.0
+000001
+
+048000_1..N20180601*1"142N"140N"139N"138N"137N"136N"135N"134N"133N"132N"131N"130N"129N"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110Nځځ"109N"108N"107N"106N"104N"103N"101N3
+000001v"(
+
+048000_1..N20180601*1(:142N
+000003
+
+048400_1..N20180601*1"139N"138N"137N"136N"135N"134N"133N"132N"131N"130N"129N"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108Nځځ"107N"106N"104N"103N"101N3
+000003v"(
+
+048400_1..N20180601*1(:139N
+000005
+
+048800_1..N20180601*1"136N"135N"134N"133N"132N"131N"130N"129N"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104Nځځ"103N"101N3
+000005v"(
+
+048800_1..N20180601*1(:136N
+000007
+
+049200_1..N20180601*1"134N"133N"132N"131N"130N"129N"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000007v"(
+
+049200_1..N20180601*1(:134N
+000009
+
+049600_1..N20180601*1"131N"130N"129N"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000009v"(
+
+049600_1..N20180601*1(:131N
+000011
+
+050000_1..N20180601*1"128N"127N"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000011v"(
+
+050000_1..N20180601*1(:128N
+000013
+
+050400_1..N20180601*1"126N"125N"124N"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000013v"(
+
+050400_1..N20180601*1(:126N
+000015
+
+050800_1..N20180601*1"123N"122N"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000015v"(
+
+050800_1..N20180601*1(:123N
+000017
+
+051200_1..N20180601*1"121N"120N"119N"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000017v"(
+
+051200_1..N20180601*1(:121N
+000019
+
+051600_1..N20180601*1"118N"117N"116N"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000019v"(
+
+051600_1..N20180601*1(:118N
+000021
+
+052000_1..N20180601*1"115N"114N"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000021v"(
+
+052000_1..N20180601*1(:115N
+000023
+
+052400_1..N20180601*1"113N"112N"111N"110N"109N"108N"107N"106N"104N"103N"101N3
+000023v"(
+
+052400_1..N20180601*1(:113N
+000025
+
+052800_1..N20180601*1"110N"109N"108N"107N"106N"104N"103N"101N3
+000025v"(
+
+052800_1..N20180601*1(:110N
+000027
+
+053200_1..N20180601*1"108N"107N"106N"104N"103N"101N3
+000027v"(
+
+053200_1..N20180601*1(:108Nn
+000029d
+
+053600_1..N20180601*1"104N"103N"101N3
+000029v"(
+
+053600_1..N20180601*1(:104N
+000031
+
+048000_1..S20180601*1"101S"103S"104S"106S"107S"108S"109S"110S"111S"112S"113S"114S"115S"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134Sځځ"135S"136S"137S"138S"139S"140S"142S3
+000031v"(
+
+048000_1..S20180601*1(:101S
+000033
+
+048400_1..S20180601*1"104S"106S"107S"108S"109S"110S"111S"112S"113S"114S"115S"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136Sځځ"137S"138S"139S"140S"142S3
+000033v"(
+
+048400_1..S20180601*1(:104S
+000035
+
+048800_1..S20180601*1"108S"109S"110S"111S"112S"113S"114S"115S"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139Sځځ"140S"142S3
+000035v"(
+
+048800_1..S20180601*1(:108S
+000037
+
+049200_1..S20180601*1"110S"111S"112S"113S"114S"115S"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000037v"(
+
+049200_1..S20180601*1(:110S
+000039
+
+049600_1..S20180601*1"113S"114S"115S"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000039v"(
+
+049600_1..S20180601*1(:113S
+000041
+
+050000_1..S20180601*1"116S"117S"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000041v"(
+
+050000_1..S20180601*1(:116S
+000043
+
+050400_1..S20180601*1"118S"119S"120S"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000043v"(
+
+050400_1..S20180601*1(:118S
+000045
+
+050800_1..S20180601*1"121S"122S"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000045v"(
+
+050800_1..S20180601*1(:121S
+000047
+
+051200_1..S20180601*1"123S"124S"125S"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000047v"(
+
+051200_1..S20180601*1(:123S
+000049
+
+051600_1..S20180601*1"126S"127S"128S"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000049v"(
+
+051600_1..S20180601*1(:126S
+000051
+
+052000_1..S20180601*1"129S"130S"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000051v"(
+
+052000_1..S20180601*1(:129S
+000053
+
+052400_1..S20180601*1"131S"132S"133S"134S"135S"136S"137S"138S"139S"140S"142S3
+000053v"(
+
+052400_1..S20180601*1(:131S
+000055
+
+052800_1..S20180601*1"134S"135S"136S"137S"138S"139S"140S"142S3
+000055v"(
+
+052800_1..S20180601*1(:134S
+000057
+
+053200_1..S20180601*1"136S"137S"138S"139S"140S"142S3
+000057v"(
+
+053200_1..S20180601*1(:136Sn
+000059d
+
+053600_1..S20180601*1"139S"140S"142S3
+000059v"(
+
+053600_1..S20180601*1(:139S
+
+000061
+
+
+048000_2..N20180601*2"257N"256N"255N"254N"253N"252N"251N"250N"249N"248N"247N"246N"245N"244N"243N"242N"241N"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225Nځځ"224N"222N"221N"220N"219N"218N"217NІІ"216N"215N"214Nވވ"213N"212N"211N"210NƋƋ"209N"208N"207Nԍԍ"206N"205N"204N"201N3
+000061v"(
+
+048000_2..N20180601*2(:257N	
+000063	
+
+048400_2..N20180601*2"254N"253N"252N"251N"250N"249N"248N"247N"246N"245N"244N"243N"242N"241N"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221Nځځ"220N"219N"218N"217N"216N"215N"214NІІ"213N"212N"211Nވވ"210N"209N"208N"207NƋƋ"206N"205N"204Nԍԍ"201N3
+000063v"(
+
+048400_2..N20180601*2(:254N
+000065
+
+048800_2..N20180601*2"250N"249N"248N"247N"246N"245N"244N"243N"242N"241N"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217Nځځ"216N"215N"214N"213N"212N"211N"210NІІ"209N"208N"207Nވވ"206N"205N"204N"201N3
+000065v"(
+
+048800_2..N20180601*2(:250N
+000067
+
+049200_2..N20180601*2"247N"246N"245N"244N"243N"242N"241N"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214Nځځ"213N"212N"211N"210N"209N"208N"207NІІ"206N"205N"204Nވވ"201N3
+000067v"(
+
+049200_2..N20180601*2(:247N
+000069
+
+049600_2..N20180601*2"243N"242N"241N"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210Nځځ"209N"208N"207N"206N"205N"204N"201N3
+000069v"(
+
+049600_2..N20180601*2(:243N
+000071
+
+050000_2..N20180601*2"239N"238N"237N"236N"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207Nځځ"206N"205N"204N"201N3
+000071v"(
+
+050000_2..N20180601*2(:239N
+000073
+
+050400_2..N20180601*2"235N"234N"233N"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000073v"(
+
+050400_2..N20180601*2(:235N
+000075
+
+050800_2..N20180601*2"232N"231N"230N"229N"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000075v"(
+
+050800_2..N20180601*2(:232N
+000077
+
+051200_2..N20180601*2"228N"227N"226N"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000077v"(
+
+051200_2..N20180601*2(:228N
+000079
+
+051600_2..N20180601*2"225N"224N"222N"221N"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000079v"(
+
+051600_2..N20180601*2(:225N
+000081
+
+052000_2..N20180601*2"220N"219N"218N"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000081v"(
+
+052000_2..N20180601*2(:220N
+000083
+
+052400_2..N20180601*2"217N"216N"215N"214N"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000083v"(
+
+052400_2..N20180601*2(:217N
+000085
+
+052800_2..N20180601*2"213N"212N"211N"210N"209N"208N"207N"206N"205N"204N"201N3
+000085v"(
+
+052800_2..N20180601*2(:213N
+000087
+
+053200_2..N20180601*2"210N"209N"208N"207N"206N"205N"204N"201N3
+000087v"(
+
+053200_2..N20180601*2(:210N
+000089|
+
+053600_2..N20180601*2"206N"205N"204N"201N3
+000089v"(
+
+053600_2..N20180601*2(:206N
+
+000091
+
+
+048000_2..S20180601*2"201S"204S"205S"206S"207S"208S"209S"210S"211S"212S"213S"214S"215S"216S"217S"218S"219S"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235Sځځ"236S"237S"238S"239S"241S"242S"243SІІ"244S"245S"246Sވވ"247S"248S"249S"250SƋƋ"251S"252S"253Sԍԍ"254S"255S"256S"257S3
+000091v"(
+
+048000_2..S20180601*2(:201S	
+000093	
+
+048400_2..S20180601*2"206S"207S"208S"209S"210S"211S"212S"213S"214S"215S"216S"217S"218S"219S"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238Sځځ"239S"241S"242S"243S"244S"245S"246SІІ"247S"248S"249Sވވ"250S"251S"252S"253SƋƋ"254S"255S"256Sԍԍ"257S3
+000093v"(
+
+048400_2..S20180601*2(:206S
+000095
+
+048800_2..S20180601*2"210S"211S"212S"213S"214S"215S"216S"217S"218S"219S"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243Sځځ"244S"245S"246S"247S"248S"249S"250SІІ"251S"252S"253Sވވ"254S"255S"256S"257S3
+000095v"(
+
+048800_2..S20180601*2(:210S
+000097
+
+049200_2..S20180601*2"213S"214S"215S"216S"217S"218S"219S"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246Sځځ"247S"248S"249S"250S"251S"252S"253SІІ"254S"255S"256Sވވ"257S3
+000097v"(
+
+049200_2..S20180601*2(:213S
+000099
+
+049600_2..S20180601*2"217S"218S"219S"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250Sځځ"251S"252S"253S"254S"255S"256S"257S3
+000099v"(
+
+049600_2..S20180601*2(:217S
+000101
+
+050000_2..S20180601*2"220S"221S"222S"224S"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253Sځځ"254S"255S"256S"257S3
+000101v"(
+
+050000_2..S20180601*2(:220S
+000103
+
+050400_2..S20180601*2"225S"226S"227S"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000103v"(
+
+050400_2..S20180601*2(:225S
+000105
+
+050800_2..S20180601*2"228S"229S"230S"231S"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000105v"(
+
+050800_2..S20180601*2(:228S
+000107
+
+051200_2..S20180601*2"232S"233S"234S"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000107v"(
+
+051200_2..S20180601*2(:232S
+000109
+
+051600_2..S20180601*2"235S"236S"237S"238S"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000109v"(
+
+051600_2..S20180601*2(:235S
+000111
+
+052000_2..S20180601*2"239S"241S"242S"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000111v"(
+
+052000_2..S20180601*2(:239S
+000113
+
+052400_2..S20180601*2"243S"244S"245S"246S"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000113v"(
+
+052400_2..S20180601*2(:243S
+000115
+
+052800_2..S20180601*2"247S"248S"249S"250S"251S"252S"253S"254S"255S"256S"257S3
+000115v"(
+
+052800_2..S20180601*2(:247S
+000117
+
+053200_2..S20180601*2"250S"251S"252S"253S"254S"255S"256S"257S3
+000117v"(
+
+053200_2..S20180601*2(:250S
+000119|
+
+053600_2..S20180601*2"254S"255S"256S"257S3
+000119v"(
+
+053600_2..S20180601*2(:254SV
+000121L
+
+048000_3..N20180601*3"302N"301N3
+000121v"(
+
+048000_3..N20180601*3(:302NV
+000123L
+
+048400_3..N20180601*3"302N"301N3
+000123v"(
+
+048400_3..N20180601*3(:302NV
+000125L
+
+048800_3..N20180601*3"302N"301N3
+000125v"(
+
+048800_3..N20180601*3(:302NV
+000127L
+
+049200_3..N20180601*3"302N"301N3
+000127v"(
+
+049200_3..N20180601*3(:302NV
+000129L
+
+049600_3..N20180601*3"302N"301N3
+000129v"(
+
+049600_3..N20180601*3(:302NV
+000131L
+
+050000_3..N20180601*3"302N"301N3
+000131v"(
+
+050000_3..N20180601*3(:302NV
+000133L
+
+050400_3..N20180601*3"302N"301N3
+000133v"(
+
+050400_3..N20180601*3(:302NV
+000135L
+
+050800_3..N20180601*3"302N"301N3
+000135v"(
+
+050800_3..N20180601*3(:302N>
+0001374
+
+051200_3..N20180601*3"301N3
+000137v"(
+
+051200_3..N20180601*3(:301N>
+0001394
+
+051600_3..N20180601*3"301N3
+000139v"(
+
+051600_3..N20180601*3(:301N>
+0001414
+
+052000_3..N20180601*3"301N3
+000141v"(
+
+052000_3..N20180601*3(:301N>
+0001434
+
+052400_3..N20180601*3"301N3
+000143v"(
+
+052400_3..N20180601*3(:301N>
+0001454
+
+052800_3..N20180601*3"301N3
+000145v"(
+
+052800_3..N20180601*3(:301N>
+0001474
+
+053200_3..N20180601*3"301N3
+000147v"(
+
+053200_3..N20180601*3(:301N>
+0001494
+
+053600_3..N20180601*3"301N3
+000149v"(
+
+053600_3..N20180601*3(:301NV
+000151L
+
+048000_3..S20180601*3"301S"302S3
+000151v"(
+
+048000_3..S20180601*3(:301SV
+000153L
+
+048400_3..S20180601*3"301S"302S3
+000153v"(
+
+048400_3..S20180601*3(:301SV
+000155L
+
+048800_3..S20180601*3"301S"302S3
+000155v"(
+
+048800_3..S20180601*3(:301SV
+000157L
+
+049200_3..S20180601*3"301S"302S3
+000157v"(
+
+049200_3..S20180601*3(:301SV
+000159L
+
+049600_3..S20180601*3"301S"302S3
+000159v"(
+
+049600_3..S20180601*3(:301SV
+000161L
+
+050000_3..S20180601*3"301S"302S3
+000161v"(
+
+050000_3..S20180601*3(:301SV
+000163L
+
+050400_3..S20180601*3"301S"302S3
+000163v"(
+
+050400_3..S20180601*3(:301SV
+000165L
+
+050800_3..S20180601*3"301S"302S3
+000165v"(
+
+050800_3..S20180601*3(:301S>
+0001674
+
+051200_3..S20180601*3"302S3
+000167v"(
+
+051200_3..S20180601*3(:302S>
+0001694
+
+051600_3..S20180601*3"302S3
+000169v"(
+
+051600_3..S20180601*3(:302S>
+0001714
+
+052000_3..S20180601*3"302S3
+000171v"(
+
+052000_3..S20180601*3(:302S>
+0001734
+
+052400_3..S20180601*3"302S3
+000173v"(
+
+052400_3..S20180601*3(:302S>
+0001754
+
+052800_3..S20180601*3"302S3
+000175v"(
+
+052800_3..S20180601*3(:302S>
+0001774
+
+053200_3..S20180601*3"302S3
+000177v"(
+
+053200_3..S20180601*3(:302S>
+0001794
+
+053600_3..S20180601*3"302S3
+000179v"(
+
+053600_3..S20180601*3(:302S
+000181
+
+048000_4..N20180601*4"423N"420N"419N"418N"416N"415N"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000181v"(
+
+048000_4..N20180601*4(:423N
+000183
+
+048400_4..N20180601*4"420N"419N"418N"416N"415N"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000183v"(
+
+048400_4..N20180601*4(:420N
+000185
+
+048800_4..N20180601*4"419N"418N"416N"415N"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000185v"(
+
+048800_4..N20180601*4(:419N
+000187
+
+049200_4..N20180601*4"418N"416N"415N"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000187v"(
+
+049200_4..N20180601*4(:418N
+000189
+
+049600_4..N20180601*4"416N"415N"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000189v"(
+
+049600_4..N20180601*4(:416N
+000191
+
+050000_4..N20180601*4"414N"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000191v"(
+
+050000_4..N20180601*4(:414N
+000193
+
+050400_4..N20180601*4"413N"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000193v"(
+
+050400_4..N20180601*4(:413N
+000195
+
+050800_4..N20180601*4"412N"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000195v"(
+
+050800_4..N20180601*4(:412N
+000197
+
+051200_4..N20180601*4"411N"410N"409N"408N"407N"406N"405N"402N"401N3
+000197v"(
+
+051200_4..N20180601*4(:411N
+000199
+
+051600_4..N20180601*4"410N"409N"408N"407N"406N"405N"402N"401N3
+000199v"(
+
+051600_4..N20180601*4(:410N
+000201
+
+052000_4..N20180601*4"408N"407N"406N"405N"402N"401N3
+000201v"(
+
+052000_4..N20180601*4(:408N
+000203
+
+052400_4..N20180601*4"407N"406N"405N"402N"401N3
+000203v"(
+
+052400_4..N20180601*4(:407N
+000205|
+
+052800_4..N20180601*4"406N"405N"402N"401N3
+000205v"(
+
+052800_4..N20180601*4(:406Nn
+000207d
+
+053200_4..N20180601*4"405N"402N"401N3
+000207v"(
+
+053200_4..N20180601*4(:405NV
+000209L
+
+053600_4..N20180601*4"402N"401N3
+000209v"(
+
+053600_4..N20180601*4(:402N
+000211
+
+048000_4..S20180601*4"401S"402S"405S"406S"407S"408S"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000211v"(
+
+048000_4..S20180601*4(:401S
+000213
+
+048400_4..S20180601*4"402S"405S"406S"407S"408S"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000213v"(
+
+048400_4..S20180601*4(:402S
+000215
+
+048800_4..S20180601*4"405S"406S"407S"408S"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000215v"(
+
+048800_4..S20180601*4(:405S
+000217
+
+049200_4..S20180601*4"406S"407S"408S"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000217v"(
+
+049200_4..S20180601*4(:406S
+000219
+
+049600_4..S20180601*4"407S"408S"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000219v"(
+
+049600_4..S20180601*4(:407S
+000221
+
+050000_4..S20180601*4"409S"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000221v"(
+
+050000_4..S20180601*4(:409S
+000223
+
+050400_4..S20180601*4"410S"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000223v"(
+
+050400_4..S20180601*4(:410S
+000225
+
+050800_4..S20180601*4"411S"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000225v"(
+
+050800_4..S20180601*4(:411S
+000227
+
+051200_4..S20180601*4"412S"413S"414S"415S"416S"418S"419S"420S"423S3
+000227v"(
+
+051200_4..S20180601*4(:412S
+000229
+
+051600_4..S20180601*4"413S"414S"415S"416S"418S"419S"420S"423S3
+000229v"(
+
+051600_4..S20180601*4(:413S
+000231
+
+052000_4..S20180601*4"415S"416S"418S"419S"420S"423S3
+000231v"(
+
+052000_4..S20180601*4(:415S
+000233
+
+052400_4..S20180601*4"416S"418S"419S"420S"423S3
+000233v"(
+
+052400_4..S20180601*4(:416S
+000235|
+
+052800_4..S20180601*4"418S"419S"420S"423S3
+000235v"(
+
+052800_4..S20180601*4(:418Sn
+000237d
+
+053200_4..S20180601*4"419S"420S"423S3
+000237v"(
+
+053200_4..S20180601*4(:419SV
+000239L
+
+053600_4..S20180601*4"420S"423S3
+000239v"(
+
+053600_4..S20180601*4(:420S
+000241
+
+048000_5..N20180601*5"505N"504N"503N"502N"501N3
+000241v"(
+
+048000_5..N20180601*5(:505N
+000243
+
+048400_5..N20180601*5"505N"504N"503N"502N"501N3
+000243v"(
+
+048400_5..N20180601*5(:505N
+000245
+
+048800_5..N20180601*5"505N"504N"503N"502N"501N3
+000245v"(
+
+048800_5..N20180601*5(:505N
+000247|
+
+049200_5..N20180601*5"504N"503N"502N"501N3
+000247v"(
+
+049200_5..N20180601*5(:504N
+000249|
+
+049600_5..N20180601*5"504N"503N"502N"501N3
+000249v"(
+
+049600_5..N20180601*5(:504N
+000251|
+
+050000_5..N20180601*5"504N"503N"502N"501N3
+000251v"(
+
+050000_5..N20180601*5(:504Nn
+000253d
+
+050400_5..N20180601*5"503N"502N"501N3
+000253v"(
+
+050400_5..N20180601*5(:503Nn
+000255d
+
+050800_5..N20180601*5"503N"502N"501N3
+000255v"(
+
+050800_5..N20180601*5(:503Nn
+000257d
+
+051200_5..N20180601*5"503N"502N"501N3
+000257v"(
+
+051200_5..N20180601*5(:503NV
+000259L
+
+051600_5..N20180601*5"502N"501N3
+000259v"(
+
+051600_5..N20180601*5(:502NV
+000261L
+
+052000_5..N20180601*5"502N"501N3
+000261v"(
+
+052000_5..N20180601*5(:502NV
+000263L
+
+052400_5..N20180601*5"502N"501N3
+000263v"(
+
+052400_5..N20180601*5(:502N>
+0002654
+
+052800_5..N20180601*5"501N3
+000265v"(
+
+052800_5..N20180601*5(:501N>
+0002674
+
+053200_5..N20180601*5"501N3
+000267v"(
+
+053200_5..N20180601*5(:501N>
+0002694
+
+053600_5..N20180601*5"501N3
+000269v"(
+
+053600_5..N20180601*5(:501N
+000271
+
+048000_5..S20180601*5"501S"502S"503S"504S"505S3
+000271v"(
+
+048000_5..S20180601*5(:501S
+000273
+
+048400_5..S20180601*5"501S"502S"503S"504S"505S3
+000273v"(
+
+048400_5..S20180601*5(:501S
+000275
+
+048800_5..S20180601*5"501S"502S"503S"504S"505S3
+000275v"(
+
+048800_5..S20180601*5(:501S
+000277|
+
+049200_5..S20180601*5"502S"503S"504S"505S3
+000277v"(
+
+049200_5..S20180601*5(:502S
+000279|
+
+049600_5..S20180601*5"502S"503S"504S"505S3
+000279v"(
+
+049600_5..S20180601*5(:502S
+000281|
+
+050000_5..S20180601*5"502S"503S"504S"505S3
+000281v"(
+
+050000_5..S20180601*5(:502Sn
+000283d
+
+050400_5..S20180601*5"503S"504S"505S3
+000283v"(
+
+050400_5..S20180601*5(:503Sn
+000285d
+
+050800_5..S20180601*5"503S"504S"505S3
+000285v"(
+
+050800_5..S20180601*5(:503Sn
+000287d
+
+051200_5..S20180601*5"503S"504S"505S3
+000287v"(
+
+051200_5..S20180601*5(:503SV
+000289L
+
+051600_5..S20180601*5"504S"505S3
+000289v"(
+
+051600_5..S20180601*5(:504SV
+000291L
+
+052000_5..S20180601*5"504S"505S3
+000291v"(
+
+052000_5..S20180601*5(:504SV
+000293L
+
+052400_5..S20180601*5"504S"505S3
+000293v"(
+
+052400_5..S20180601*5(:504S>
+0002954
+
+052800_5..S20180601*5"505S3
+000295v"(
+
+052800_5..S20180601*5(:505S>
+0002974
+
+053200_5..S20180601*5"505S3
+000297v"(
+
+053200_5..S20180601*5(:505S>
+0002994
+
+053600_5..S20180601*5"505S3
+000299v"(
+
+053600_5..S20180601*5(:505S
+000301
+
+048000_6..N20180601*6"640N"639N"638N"637N"636N"635N"634N"633N"632N"631N"630N"629N"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608Nځځ"607N"606N"604N"603N"602N"601N3
+000301v"(
+
+048000_6..N20180601*6(:640N
+000303
+
+048400_6..N20180601*6"638N"637N"636N"635N"634N"633N"632N"631N"630N"629N"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606Nځځ"604N"603N"602N"601N3
+000303v"(
+
+048400_6..N20180601*6(:638N
+000305
+
+048800_6..N20180601*6"635N"634N"633N"632N"631N"630N"629N"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602Nځځ"601N3
+000305v"(
+
+048800_6..N20180601*6(:635N
+000307
+
+049200_6..N20180601*6"633N"632N"631N"630N"629N"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000307v"(
+
+049200_6..N20180601*6(:633N
+000309
+
+049600_6..N20180601*6"630N"629N"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000309v"(
+
+049600_6..N20180601*6(:630N
+000311
+
+050000_6..N20180601*6"628N"627N"626N"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000311v"(
+
+050000_6..N20180601*6(:628N
+000313
+
+050400_6..N20180601*6"625N"624N"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000313v"(
+
+050400_6..N20180601*6(:625N
+000315
+
+050800_6..N20180601*6"623N"622N"621N"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000315v"(
+
+050800_6..N20180601*6(:623N
+000317
+
+051200_6..N20180601*6"619N"618N"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000317v"(
+
+051200_6..N20180601*6(:619N
+000319
+
+051600_6..N20180601*6"617N"616N"615N"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000319v"(
+
+051600_6..N20180601*6(:617N
+000321
+
+052000_6..N20180601*6"614N"613N"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000321v"(
+
+052000_6..N20180601*6(:614N
+000323
+
+052400_6..N20180601*6"612N"611N"610N"609N"608N"607N"606N"604N"603N"602N"601N3
+000323v"(
+
+052400_6..N20180601*6(:612N
+000325
+
+052800_6..N20180601*6"609N"608N"607N"606N"604N"603N"602N"601N3
+000325v"(
+
+052800_6..N20180601*6(:609N
+000327
+
+053200_6..N20180601*6"607N"606N"604N"603N"602N"601N3
+000327v"(
+
+053200_6..N20180601*6(:607Nn
+000329d
+
+053600_6..N20180601*6"603N"602N"601N3
+000329v"(
+
+053600_6..N20180601*6(:603N
+000331
+
+048000_6..S20180601*6"601S"602S"603S"604S"606S"607S"608S"609S"610S"611S"612S"613S"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634Sځځ"635S"636S"637S"638S"639S"640S3
+000331v"(
+
+048000_6..S20180601*6(:601S
+000333
+
+048400_6..S20180601*6"603S"604S"606S"607S"608S"609S"610S"611S"612S"613S"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636Sځځ"637S"638S"639S"640S3
+000333v"(
+
+048400_6..S20180601*6(:603S
+000335
+
+048800_6..S20180601*6"607S"608S"609S"610S"611S"612S"613S"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639Sځځ"640S3
+000335v"(
+
+048800_6..S20180601*6(:607S
+000337
+
+049200_6..S20180601*6"609S"610S"611S"612S"613S"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000337v"(
+
+049200_6..S20180601*6(:609S
+000339
+
+049600_6..S20180601*6"612S"613S"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000339v"(
+
+049600_6..S20180601*6(:612S
+000341
+
+050000_6..S20180601*6"614S"615S"616S"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000341v"(
+
+050000_6..S20180601*6(:614S
+000343
+
+050400_6..S20180601*6"617S"618S"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000343v"(
+
+050400_6..S20180601*6(:617S
+000345
+
+050800_6..S20180601*6"619S"621S"622S"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000345v"(
+
+050800_6..S20180601*6(:619S
+000347
+
+051200_6..S20180601*6"623S"624S"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000347v"(
+
+051200_6..S20180601*6(:623S
+000349
+
+051600_6..S20180601*6"625S"626S"627S"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000349v"(
+
+051600_6..S20180601*6(:625S
+000351
+
+052000_6..S20180601*6"628S"629S"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000351v"(
+
+052000_6..S20180601*6(:628S
+000353
+
+052400_6..S20180601*6"630S"631S"632S"633S"634S"635S"636S"637S"638S"639S"640S3
+000353v"(
+
+052400_6..S20180601*6(:630S
+000355
+
+052800_6..S20180601*6"633S"634S"635S"636S"637S"638S"639S"640S3
+000355v"(
+
+052800_6..S20180601*6(:633S
+000357
+
+053200_6..S20180601*6"635S"636S"637S"638S"639S"640S3
+000357v"(
+
+053200_6..S20180601*6(:635Sn
+000359d
+
+053600_6..S20180601*6"638S"639S"640S3
+000359v"(
+
+053600_6..S20180601*6(:638SX
+000361N
+
+048000_GS..N20180601*GS"902N"901N5
+000361v"*
+
+048000_GS..N20180601*GS(:902NX
+000363N
+
+048400_GS..N20180601*GS"902N"901N5
+000363v"*
+
+048400_GS..N20180601*GS(:902NX
+000365N
+
+048800_GS..N20180601*GS"902N"901N5
+000365v"*
+
+048800_GS..N20180601*GS(:902NX
+000367N
+
+049200_GS..N20180601*GS"902N"901N5
+000367v"*
+
+049200_GS..N20180601*GS(:902NX
+000369N
+
+049600_GS..N20180601*GS"902N"901N5
+000369v"*
+
+049600_GS..N20180601*GS(:902NX
+000371N
+
+050000_GS..N20180601*GS"902N"901N5
+000371v"*
+
+050000_GS..N20180601*GS(:902NX
+000373N
+
+050400_GS..N20180601*GS"902N"901N5
+000373v"*
+
+050400_GS..N20180601*GS(:902NX
+000375N
+
+050800_GS..N20180601*GS"902N"901N5
+000375v"*
+
+050800_GS..N20180601*GS(:902N@
+0003776
+
+051200_GS..N20180601*GS"901N5
+000377v"*
+
+051200_GS..N20180601*GS(:901N@
+0003796
+
+051600_GS..N20180601*GS"901N5
+000379v"*
+
+051600_GS..N20180601*GS(:901N@
+0003816
+
+052000_GS..N20180601*GS"901N5
+000381v"*
+
+052000_GS..N20180601*GS(:901N@
+0003836
+
+052400_GS..N20180601*GS"901N5
+000383v"*
+
+052400_GS..N20180601*GS(:901N@
+0003856
+
+052800_GS..N20180601*GS"901N5
+000385v"*
+
+052800_GS..N20180601*GS(:901N@
+0003876
+
+053200_GS..N20180601*GS"901N5
+000387v"*
+
+053200_GS..N20180601*GS(:901N@
+0003896
+
+053600_GS..N20180601*GS"901N5
+000389v"*
+
+053600_GS..N20180601*GS(:901NX
+000391N
+
+048000_GS..S20180601*GS"901S"902S5
+000391v"*
+
+048000_GS..S20180601*GS(:901SX
+000393N
+
+048400_GS..S20180601*GS"901S"902S5
+000393v"*
+
+048400_GS..S20180601*GS(:901SX
+000395N
+
+048800_GS..S20180601*GS"901S"902S5
+000395v"*
+
+048800_GS..S20180601*GS(:901SX
+000397N
+
+049200_GS..S20180601*GS"901S"902S5
+000397v"*
+
+049200_GS..S20180601*GS(:901SX
+000399N
+
+049600_GS..S20180601*GS"901S"902S5
+000399v"*
+
+049600_GS..S20180601*GS(:901SX
+000401N
+
+050000_GS..S20180601*GS"901S"902S5
+000401v"*
+
+050000_GS..S20180601*GS(:901SX
+000403N
+
+050400_GS..S20180601*GS"901S"902S5
+000403v"*
+
+050400_GS..S20180601*GS(:901SX
+000405N
+
+050800_GS..S20180601*GS"901S"902S5
+000405v"*
+
+050800_GS..S20180601*GS(:901S@
+0004076
+
+051200_GS..S20180601*GS"902S5
+000407v"*
+
+051200_GS..S20180601*GS(:902S@
+0004096
+
+051600_GS..S20180601*GS"902S5
+000409v"*
+
+051600_GS..S20180601*GS(:902S@
+0004116
+
+052000_GS..S20180601*GS"902S5
+000411v"*
+
+052000_GS..S20180601*GS(:902S@
+0004136
+
+052400_GS..S20180601*GS"902S5
+000413v"*
+
+052400_GS..S20180601*GS(:902S@
+0004156
+
+052800_GS..S20180601*GS"902S5
+000415v"*
+
+052800_GS..S20180601*GS(:902S@
+0004176
+
+053200_GS..S20180601*GS"902S5
+000417v"*
+
+053200_GS..S20180601*GS(:902S@
+0004196
+
+053600_GS..S20180601*GS"902S5
+000419v"*
+
+053600_GS..S20180601*GS(:902S
\ No newline at end of file
@@ -0,0 +1,543 @@
+
+
+1.0
+000001
+
+048000_N..N20180601*N"N12N"N10N"N09N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000001v"(
+
+048000_N..N20180601*N(:N12N
+000003
+
+048400_N..N20180601*N"N12N"N10N"N09N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000003v"(
+
+048400_N..N20180601*N(:N12N
+000005
+
+048800_N..N20180601*N"N10N"N09N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000005v"(
+
+048800_N..N20180601*N(:N10N
+000007
+
+049200_N..N20180601*N"N09N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000007v"(
+
+049200_N..N20180601*N(:N09N
+000009
+
+049600_N..N20180601*N"N09N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000009v"(
+
+049600_N..N20180601*N(:N09N
+000011
+
+050000_N..N20180601*N"N08N"N07N"N06N"N05N"N04N"N03N"N02N3
+000011v"(
+
+050000_N..N20180601*N(:N08N
+000013
+
+050400_N..N20180601*N"N07N"N06N"N05N"N04N"N03N"N02N3
+000013v"(
+
+050400_N..N20180601*N(:N07N
+000015
+
+050800_N..N20180601*N"N07N"N06N"N05N"N04N"N03N"N02N3
+000015v"(
+
+050800_N..N20180601*N(:N07N
+000017
+
+051200_N..N20180601*N"N06N"N05N"N04N"N03N"N02N3
+000017v"(
+
+051200_N..N20180601*N(:N06N
+000019|
+
+051600_N..N20180601*N"N05N"N04N"N03N"N02N3
+000019v"(
+
+051600_N..N20180601*N(:N05N
+000021|
+
+052000_N..N20180601*N"N05N"N04N"N03N"N02N3
+000021v"(
+
+052000_N..N20180601*N(:N05Nn
+000023d
+
+052400_N..N20180601*N"N04N"N03N"N02N3
+000023v"(
+
+052400_N..N20180601*N(:N04NV
+000025L
+
+052800_N..N20180601*N"N03N"N02N3
+000025v"(
+
+052800_N..N20180601*N(:N03NV
+000027L
+
+053200_N..N20180601*N"N03N"N02N3
+000027v"(
+
+053200_N..N20180601*N(:N03N>
+0000294
+
+053600_N..N20180601*N"N02N3
+000029v"(
+
+053600_N..N20180601*N(:N02N
+000031
+
+048000_N..S20180601*N"N02S"N03S"N04S"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000031v"(
+
+048000_N..S20180601*N(:N02S
+000033
+
+048400_N..S20180601*N"N02S"N03S"N04S"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000033v"(
+
+048400_N..S20180601*N(:N02S
+000035
+
+048800_N..S20180601*N"N03S"N04S"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000035v"(
+
+048800_N..S20180601*N(:N03S
+000037
+
+049200_N..S20180601*N"N04S"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000037v"(
+
+049200_N..S20180601*N(:N04S
+000039
+
+049600_N..S20180601*N"N04S"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000039v"(
+
+049600_N..S20180601*N(:N04S
+000041
+
+050000_N..S20180601*N"N05S"N06S"N07S"N08S"N09S"N10S"N12S3
+000041v"(
+
+050000_N..S20180601*N(:N05S
+000043
+
+050400_N..S20180601*N"N06S"N07S"N08S"N09S"N10S"N12S3
+000043v"(
+
+050400_N..S20180601*N(:N06S
+000045
+
+050800_N..S20180601*N"N06S"N07S"N08S"N09S"N10S"N12S3
+000045v"(
+
+050800_N..S20180601*N(:N06S
+000047
+
+051200_N..S20180601*N"N07S"N08S"N09S"N10S"N12S3
+000047v"(
+
+051200_N..S20180601*N(:N07S
+000049|
+
+051600_N..S20180601*N"N08S"N09S"N10S"N12S3
+000049v"(
+
+051600_N..S20180601*N(:N08S
+000051|
+
+052000_N..S20180601*N"N08S"N09S"N10S"N12S3
+000051v"(
+
+052000_N..S20180601*N(:N08Sn
+000053d
+
+052400_N..S20180601*N"N09S"N10S"N12S3
+000053v"(
+
+052400_N..S20180601*N(:N09SV
+000055L
+
+052800_N..S20180601*N"N10S"N12S3
+000055v"(
+
+052800_N..S20180601*N(:N10SV
+000057L
+
+053200_N..S20180601*N"N10S"N12S3
+000057v"(
+
+053200_N..S20180601*N(:N10S>
+0000594
+
+053600_N..S20180601*N"N12S3
+000059v"(
+
+053600_N..S20180601*N(:N12S
+000061|
+
+048000_Q..N20180601*Q"Q05N"Q04N"Q03N"Q01N3
+000061v"(
+
+048000_Q..N20180601*Q(:Q05N
+000063|
+
+048400_Q..N20180601*Q"Q05N"Q04N"Q03N"Q01N3
+000063v"(
+
+048400_Q..N20180601*Q(:Q05N
+000065|
+
+048800_Q..N20180601*Q"Q05N"Q04N"Q03N"Q01N3
+000065v"(
+
+048800_Q..N20180601*Q(:Q05N
+000067|
+
+049200_Q..N20180601*Q"Q05N"Q04N"Q03N"Q01N3
+000067v"(
+
+049200_Q..N20180601*Q(:Q05Nn
+000069d
+
+049600_Q..N20180601*Q"Q04N"Q03N"Q01N3
+000069v"(
+
+049600_Q..N20180601*Q(:Q04Nn
+000071d
+
+050000_Q..N20180601*Q"Q04N"Q03N"Q01N3
+000071v"(
+
+050000_Q..N20180601*Q(:Q04Nn
+000073d
+
+050400_Q..N20180601*Q"Q04N"Q03N"Q01N3
+000073v"(
+
+050400_Q..N20180601*Q(:Q04Nn
+000075d
+
+050800_Q..N20180601*Q"Q04N"Q03N"Q01N3
+000075v"(
+
+050800_Q..N20180601*Q(:Q04NV
+000077L
+
+051200_Q..N20180601*Q"Q03N"Q01N3
+000077v"(
+
+051200_Q..N20180601*Q(:Q03NV
+000079L
+
+051600_Q..N20180601*Q"Q03N"Q01N3
+000079v"(
+
+051600_Q..N20180601*Q(:Q03NV
+000081L
+
+052000_Q..N20180601*Q"Q03N"Q01N3
+000081v"(
+
+052000_Q..N20180601*Q(:Q03NV
+000083L
+
+052400_Q..N20180601*Q"Q03N"Q01N3
+000083v"(
+
+052400_Q..N20180601*Q(:Q03N>
+0000854
+
+052800_Q..N20180601*Q"Q01N3
+000085v"(
+
+052800_Q..N20180601*Q(:Q01N>
+0000874
+
+053200_Q..N20180601*Q"Q01N3
+000087v"(
+
+053200_Q..N20180601*Q(:Q01N>
+0000894
+
+053600_Q..N20180601*Q"Q01N3
+000089v"(
+
+053600_Q..N20180601*Q(:Q01N
+000091|
+
+048000_Q..S20180601*Q"Q01S"Q03S"Q04S"Q05S3
+000091v"(
+
+048000_Q..S20180601*Q(:Q01S
+000093|
+
+048400_Q..S20180601*Q"Q01S"Q03S"Q04S"Q05S3
+000093v"(
+
+048400_Q..S20180601*Q(:Q01S
+000095|
+
+048800_Q..S20180601*Q"Q01S"Q03S"Q04S"Q05S3
+000095v"(
+
+048800_Q..S20180601*Q(:Q01S
+000097|
+
+049200_Q..S20180601*Q"Q01S"Q03S"Q04S"Q05S3
+000097v"(
+
+049200_Q..S20180601*Q(:Q01Sn
+000099d
+
+049600_Q..S20180601*Q"Q03S"Q04S"Q05S3
+000099v"(
+
+049600_Q..S20180601*Q(:Q03Sn
+000101d
+
+050000_Q..S20180601*Q"Q03S"Q04S"Q05S3
+000101v"(
+
+050000_Q..S20180601*Q(:Q03Sn
+000103d
+
+050400_Q..S20180601*Q"Q03S"Q04S"Q05S3
+000103v"(
+
+050400_Q..S20180601*Q(:Q03Sn
+000105d
+
+050800_Q..S20180601*Q"Q03S"Q04S"Q05S3
+000105v"(
+
+050800_Q..S20180601*Q(:Q03SV
+000107L
+
+051200_Q..S20180601*Q"Q04S"Q05S3
+000107v"(
+
+051200_Q..S20180601*Q(:Q04SV
+000109L
+
+051600_Q..S20180601*Q"Q04S"Q05S3
+000109v"(
+
+051600_Q..S20180601*Q(:Q04SV
+000111L
+
+052000_Q..S20180601*Q"Q04S"Q05S3
+000111v"(
+
+052000_Q..S20180601*Q(:Q04SV
+000113L
+
+052400_Q..S20180601*Q"Q04S"Q05S3
+000113v"(
+
+052400_Q..S20180601*Q(:Q04S>
+0001154
+
+052800_Q..S20180601*Q"Q05S3
+000115v"(
+
+052800_Q..S20180601*Q(:Q05S>
+0001174
+
+053200_Q..S20180601*Q"Q05S3
+000117v"(
+
+053200_Q..S20180601*Q(:Q05S>
+0001194
+
+053600_Q..S20180601*Q"Q05S3
+000119v"(
+
+053600_Q..S20180601*Q(:Q05S
+000121
+
+048000_R..N20180601*R"R45N"R44N"R43N"R42N"R41N"R40N"R39N"R36N"R35N"R34N"R33N"R32N"R31N"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11Nځځ"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000121v"(
+
+048000_R..N20180601*R(:R45N
+000123
+
+048400_R..N20180601*R"R43N"R42N"R41N"R40N"R39N"R36N"R35N"R34N"R33N"R32N"R31N"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08Nځځ"R06N"R05N"R04N"R03N"R01N3
+000123v"(
+
+048400_R..N20180601*R(:R43N
+000125
+
+048800_R..N20180601*R"R40N"R39N"R36N"R35N"R34N"R33N"R32N"R31N"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04Nځځ"R03N"R01N3
+000125v"(
+
+048800_R..N20180601*R(:R40N
+000127
+
+049200_R..N20180601*R"R36N"R35N"R34N"R33N"R32N"R31N"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000127v"(
+
+049200_R..N20180601*R(:R36N
+000129
+
+049600_R..N20180601*R"R33N"R32N"R31N"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000129v"(
+
+049600_R..N20180601*R(:R33N
+000131
+
+050000_R..N20180601*R"R30N"R29N"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000131v"(
+
+050000_R..N20180601*R(:R30N
+000133
+
+050400_R..N20180601*R"R28N"R27N"R26N"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000133v"(
+
+050400_R..N20180601*R(:R28N
+000135
+
+050800_R..N20180601*R"R25N"R24N"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000135v"(
+
+050800_R..N20180601*R(:R25N
+000137
+
+051200_R..N20180601*R"R23N"R22N"R21N"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000137v"(
+
+051200_R..N20180601*R(:R23N
+000139
+
+051600_R..N20180601*R"R20N"R19N"R18N"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000139v"(
+
+051600_R..N20180601*R(:R20N
+000141
+
+052000_R..N20180601*R"R17N"R16N"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000141v"(
+
+052000_R..N20180601*R(:R17N
+000143
+
+052400_R..N20180601*R"R15N"R14N"R13N"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000143v"(
+
+052400_R..N20180601*R(:R15N
+000145
+
+052800_R..N20180601*R"R11N"R09N"R08N"R06N"R05N"R04N"R03N"R01N3
+000145v"(
+
+052800_R..N20180601*R(:R11N
+000147
+
+053200_R..N20180601*R"R08N"R06N"R05N"R04N"R03N"R01N3
+000147v"(
+
+053200_R..N20180601*R(:R08Nn
+000149d
+
+053600_R..N20180601*R"R04N"R03N"R01N3
+000149v"(
+
+053600_R..N20180601*R(:R04N
+000151
+
+048000_R..S20180601*R"R01S"R03S"R04S"R05S"R06S"R08S"R09S"R11S"R13S"R14S"R15S"R16S"R17S"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36Sځځ"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000151v"(
+
+048000_R..S20180601*R(:R01S
+000153
+
+048400_R..S20180601*R"R04S"R05S"R06S"R08S"R09S"R11S"R13S"R14S"R15S"R16S"R17S"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40Sځځ"R41S"R42S"R43S"R44S"R45S3
+000153v"(
+
+048400_R..S20180601*R(:R04S
+000155
+
+048800_R..S20180601*R"R08S"R09S"R11S"R13S"R14S"R15S"R16S"R17S"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43Sځځ"R44S"R45S3
+000155v"(
+
+048800_R..S20180601*R(:R08S
+000157
+
+049200_R..S20180601*R"R11S"R13S"R14S"R15S"R16S"R17S"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000157v"(
+
+049200_R..S20180601*R(:R11S
+000159
+
+049600_R..S20180601*R"R15S"R16S"R17S"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000159v"(
+
+049600_R..S20180601*R(:R15S
+000161
+
+050000_R..S20180601*R"R18S"R19S"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000161v"(
+
+050000_R..S20180601*R(:R18S
+000163
+
+050400_R..S20180601*R"R20S"R21S"R22S"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000163v"(
+
+050400_R..S20180601*R(:R20S
+000165
+
+050800_R..S20180601*R"R23S"R24S"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000165v"(
+
+050800_R..S20180601*R(:R23S
+000167
+
+051200_R..S20180601*R"R25S"R26S"R27S"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000167v"(
+
+051200_R..S20180601*R(:R25S
+000169
+
+051600_R..S20180601*R"R28S"R29S"R30S"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000169v"(
+
+051600_R..S20180601*R(:R28S
+000171
+
+052000_R..S20180601*R"R31S"R32S"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000171v"(
+
+052000_R..S20180601*R(:R31S
+000173
+
+052400_R..S20180601*R"R33S"R34S"R35S"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000173v"(
+
+052400_R..S20180601*R(:R33S
+000175
+
+052800_R..S20180601*R"R36S"R39S"R40S"R41S"R42S"R43S"R44S"R45S3
+000175v"(
+
+052800_R..S20180601*R(:R36S
+000177
+
+053200_R..S20180601*R"R40S"R41S"R42S"R43S"R44S"R45S3
+000177v"(
+
+053200_R..S20180601*R(:R40Sn
+000179d
+
+053600_R..S20180601*R"R43S"R44S"R45S3
+000179v"(
+
+053600_R..S20180601*R(:R43S
\ No newline at end of file
@@ -0,0 +1,183 @@
+
+
+1.0
+000001
+
+048000_L..N20180601*L"L29N"L28N"L27N"L26N"L25N"L24N"L22N"L21N"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000001v"(
+
+048000_L..N20180601*L(:L29N
+000003
+
+048400_L..N20180601*L"L28N"L27N"L26N"L25N"L24N"L22N"L21N"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000003v"(
+
+048400_L..N20180601*L(:L28N
+000005
+
+048800_L..N20180601*L"L26N"L25N"L24N"L22N"L21N"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000005v"(
+
+048800_L..N20180601*L(:L26N
+000007
+
+049200_L..N20180601*L"L25N"L24N"L22N"L21N"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000007v"(
+
+049200_L..N20180601*L(:L25N
+000009
+
+049600_L..N20180601*L"L22N"L21N"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000009v"(
+
+049600_L..N20180601*L(:L22N
+000011
+
+050000_L..N20180601*L"L20N"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000011v"(
+
+050000_L..N20180601*L(:L20N
+000013
+
+050400_L..N20180601*L"L19N"L17N"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000013v"(
+
+050400_L..N20180601*L(:L19N
+000015
+
+050800_L..N20180601*L"L16N"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000015v"(
+
+050800_L..N20180601*L(:L16N
+000017
+
+051200_L..N20180601*L"L15N"L14N"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000017v"(
+
+051200_L..N20180601*L(:L15N
+000019
+
+051600_L..N20180601*L"L13N"L12N"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000019v"(
+
+051600_L..N20180601*L(:L13N
+000021
+
+052000_L..N20180601*L"L11N"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000021v"(
+
+052000_L..N20180601*L(:L11N
+000023
+
+052400_L..N20180601*L"L10N"L08N"L06N"L05N"L03N"L02N"L01N3
+000023v"(
+
+052400_L..N20180601*L(:L10N
+000025
+
+052800_L..N20180601*L"L06N"L05N"L03N"L02N"L01N3
+000025v"(
+
+052800_L..N20180601*L(:L06N
+000027|
+
+053200_L..N20180601*L"L05N"L03N"L02N"L01N3
+000027v"(
+
+053200_L..N20180601*L(:L05NV
+000029L
+
+053600_L..N20180601*L"L02N"L01N3
+000029v"(
+
+053600_L..N20180601*L(:L02N
+000031
+
+048000_L..S20180601*L"L01S"L02S"L03S"L05S"L06S"L08S"L10S"L11S"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000031v"(
+
+048000_L..S20180601*L(:L01S
+000033
+
+048400_L..S20180601*L"L02S"L03S"L05S"L06S"L08S"L10S"L11S"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000033v"(
+
+048400_L..S20180601*L(:L02S
+000035
+
+048800_L..S20180601*L"L05S"L06S"L08S"L10S"L11S"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000035v"(
+
+048800_L..S20180601*L(:L05S
+000037
+
+049200_L..S20180601*L"L06S"L08S"L10S"L11S"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000037v"(
+
+049200_L..S20180601*L(:L06S
+000039
+
+049600_L..S20180601*L"L10S"L11S"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000039v"(
+
+049600_L..S20180601*L(:L10S
+000041
+
+050000_L..S20180601*L"L12S"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000041v"(
+
+050000_L..S20180601*L(:L12S
+000043
+
+050400_L..S20180601*L"L13S"L14S"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000043v"(
+
+050400_L..S20180601*L(:L13S
+000045
+
+050800_L..S20180601*L"L15S"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000045v"(
+
+050800_L..S20180601*L(:L15S
+000047
+
+051200_L..S20180601*L"L16S"L17S"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000047v"(
+
+051200_L..S20180601*L(:L16S
+000049
+
+051600_L..S20180601*L"L19S"L20S"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000049v"(
+
+051600_L..S20180601*L(:L19S
+000051
+
+052000_L..S20180601*L"L21S"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000051v"(
+
+052000_L..S20180601*L(:L21S
+000053
+
+052400_L..S20180601*L"L22S"L24S"L25S"L26S"L27S"L28S"L29S3
+000053v"(
+
+052400_L..S20180601*L(:L22S
+000055
+
+052800_L..S20180601*L"L25S"L26S"L27S"L28S"L29S3
+000055v"(
+
+052800_L..S20180601*L(:L25S
+000057|
+
+053200_L..S20180601*L"L26S"L27S"L28S"L29S3
+000057v"(
+
+053200_L..S20180601*L(:L26SV
+000059L
+
+053600_L..S20180601*L"L28S"L29S3
+000059v"(
+
+053600_L..S20180601*L(:L28S
\ No newline at end of file
@@ -0,0 +1,543 @@
+
+
+1.0
+000001
+
+048000_B..N20180601*B"B23N"B22N"B21N"B20N"B19N"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000001v"(
+
+048000_B..N20180601*B(:B23N
+000003
+
+048400_B..N20180601*B"B22N"B21N"B20N"B19N"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000003v"(
+
+048400_B..N20180601*B(:B22N
+000005
+
+048800_B..N20180601*B"B21N"B20N"B19N"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000005v"(
+
+048800_B..N20180601*B(:B21N
+000007
+
+049200_B..N20180601*B"B20N"B19N"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000007v"(
+
+049200_B..N20180601*B(:B20N
+000009
+
+049600_B..N20180601*B"B19N"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000009v"(
+
+049600_B..N20180601*B(:B19N
+000011
+
+050000_B..N20180601*B"B18N"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000011v"(
+
+050000_B..N20180601*B(:B18N
+000013
+
+050400_B..N20180601*B"B17N"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000013v"(
+
+050400_B..N20180601*B(:B17N
+000015
+
+050800_B..N20180601*B"B16N"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000015v"(
+
+050800_B..N20180601*B(:B16N
+000017
+
+051200_B..N20180601*B"B15N"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000017v"(
+
+051200_B..N20180601*B(:B15N
+000019
+
+051600_B..N20180601*B"B14N"B13N"B12N"B10N"B08N"B06N"B04N3
+000019v"(
+
+051600_B..N20180601*B(:B14N
+000021
+
+052000_B..N20180601*B"B13N"B12N"B10N"B08N"B06N"B04N3
+000021v"(
+
+052000_B..N20180601*B(:B13N
+000023
+
+052400_B..N20180601*B"B12N"B10N"B08N"B06N"B04N3
+000023v"(
+
+052400_B..N20180601*B(:B12N
+000025|
+
+052800_B..N20180601*B"B10N"B08N"B06N"B04N3
+000025v"(
+
+052800_B..N20180601*B(:B10Nn
+000027d
+
+053200_B..N20180601*B"B08N"B06N"B04N3
+000027v"(
+
+053200_B..N20180601*B(:B08NV
+000029L
+
+053600_B..N20180601*B"B06N"B04N3
+000029v"(
+
+053600_B..N20180601*B(:B06N
+000031
+
+048000_B..S20180601*B"B04S"B06S"B08S"B10S"B12S"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000031v"(
+
+048000_B..S20180601*B(:B04S
+000033
+
+048400_B..S20180601*B"B06S"B08S"B10S"B12S"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000033v"(
+
+048400_B..S20180601*B(:B06S
+000035
+
+048800_B..S20180601*B"B08S"B10S"B12S"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000035v"(
+
+048800_B..S20180601*B(:B08S
+000037
+
+049200_B..S20180601*B"B10S"B12S"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000037v"(
+
+049200_B..S20180601*B(:B10S
+000039
+
+049600_B..S20180601*B"B12S"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000039v"(
+
+049600_B..S20180601*B(:B12S
+000041
+
+050000_B..S20180601*B"B13S"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000041v"(
+
+050000_B..S20180601*B(:B13S
+000043
+
+050400_B..S20180601*B"B14S"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000043v"(
+
+050400_B..S20180601*B(:B14S
+000045
+
+050800_B..S20180601*B"B15S"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000045v"(
+
+050800_B..S20180601*B(:B15S
+000047
+
+051200_B..S20180601*B"B16S"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000047v"(
+
+051200_B..S20180601*B(:B16S
+000049
+
+051600_B..S20180601*B"B17S"B18S"B19S"B20S"B21S"B22S"B23S3
+000049v"(
+
+051600_B..S20180601*B(:B17S
+000051
+
+052000_B..S20180601*B"B18S"B19S"B20S"B21S"B22S"B23S3
+000051v"(
+
+052000_B..S20180601*B(:B18S
+000053
+
+052400_B..S20180601*B"B19S"B20S"B21S"B22S"B23S3
+000053v"(
+
+052400_B..S20180601*B(:B19S
+000055|
+
+052800_B..S20180601*B"B20S"B21S"B22S"B23S3
+000055v"(
+
+052800_B..S20180601*B(:B20Sn
+000057d
+
+053200_B..S20180601*B"B21S"B22S"B23S3
+000057v"(
+
+053200_B..S20180601*B(:B21SV
+000059L
+
+053600_B..S20180601*B"B22S"B23S3
+000059v"(
+
+053600_B..S20180601*B(:B22S
+000061
+
+048000_D..N20180601*D"D43N"D42N"D41N"D40N"D39N"D38N"D37N"D35N"D34N"D33N"D32N"D31N"D30N"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10Nځځ"D09N"D08N"D07N"D06N"D05N"D04N"D03NІІ"D01N3
+000061v"(
+
+048000_D..N20180601*D(:D43N
+000063
+
+048400_D..N20180601*D"D41N"D40N"D39N"D38N"D37N"D35N"D34N"D33N"D32N"D31N"D30N"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08Nځځ"D07N"D06N"D05N"D04N"D03N"D01N3
+000063v"(
+
+048400_D..N20180601*D(:D41N
+000065
+
+048800_D..N20180601*D"D38N"D37N"D35N"D34N"D33N"D32N"D31N"D30N"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05Nځځ"D04N"D03N"D01N3
+000065v"(
+
+048800_D..N20180601*D(:D38N
+000067
+
+049200_D..N20180601*D"D34N"D33N"D32N"D31N"D30N"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000067v"(
+
+049200_D..N20180601*D(:D34N
+000069
+
+049600_D..N20180601*D"D32N"D31N"D30N"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000069v"(
+
+049600_D..N20180601*D(:D32N
+000071
+
+050000_D..N20180601*D"D29N"D28N"D27N"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000071v"(
+
+050000_D..N20180601*D(:D29N
+000073
+
+050400_D..N20180601*D"D26N"D25N"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000073v"(
+
+050400_D..N20180601*D(:D26N
+000075
+
+050800_D..N20180601*D"D24N"D22N"D21N"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000075v"(
+
+050800_D..N20180601*D(:D24N
+000077
+
+051200_D..N20180601*D"D20N"D19N"D18N"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000077v"(
+
+051200_D..N20180601*D(:D20N
+000079
+
+051600_D..N20180601*D"D17N"D16N"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000079v"(
+
+051600_D..N20180601*D(:D17N
+000081
+
+052000_D..N20180601*D"D15N"D14N"D13N"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000081v"(
+
+052000_D..N20180601*D(:D15N
+000083
+
+052400_D..N20180601*D"D12N"D11N"D10N"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000083v"(
+
+052400_D..N20180601*D(:D12N
+000085
+
+052800_D..N20180601*D"D09N"D08N"D07N"D06N"D05N"D04N"D03N"D01N3
+000085v"(
+
+052800_D..N20180601*D(:D09N
+000087
+
+053200_D..N20180601*D"D07N"D06N"D05N"D04N"D03N"D01N3
+000087v"(
+
+053200_D..N20180601*D(:D07Nn
+000089d
+
+053600_D..N20180601*D"D04N"D03N"D01N3
+000089v"(
+
+053600_D..N20180601*D(:D04N
+000091
+
+048000_D..S20180601*D"D01S"D03S"D04S"D05S"D06S"D07S"D08S"D09S"D10S"D11S"D12S"D13S"D14S"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34Sځځ"D35S"D37S"D38S"D39S"D40S"D41S"D42SІІ"D43S3
+000091v"(
+
+048000_D..S20180601*D(:D01S
+000093
+
+048400_D..S20180601*D"D04S"D05S"D06S"D07S"D08S"D09S"D10S"D11S"D12S"D13S"D14S"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37Sځځ"D38S"D39S"D40S"D41S"D42S"D43S3
+000093v"(
+
+048400_D..S20180601*D(:D04S
+000095
+
+048800_D..S20180601*D"D07S"D08S"D09S"D10S"D11S"D12S"D13S"D14S"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40Sځځ"D41S"D42S"D43S3
+000095v"(
+
+048800_D..S20180601*D(:D07S
+000097
+
+049200_D..S20180601*D"D10S"D11S"D12S"D13S"D14S"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000097v"(
+
+049200_D..S20180601*D(:D10S
+000099
+
+049600_D..S20180601*D"D12S"D13S"D14S"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000099v"(
+
+049600_D..S20180601*D(:D12S
+000101
+
+050000_D..S20180601*D"D15S"D16S"D17S"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000101v"(
+
+050000_D..S20180601*D(:D15S
+000103
+
+050400_D..S20180601*D"D18S"D19S"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000103v"(
+
+050400_D..S20180601*D(:D18S
+000105
+
+050800_D..S20180601*D"D20S"D21S"D22S"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000105v"(
+
+050800_D..S20180601*D(:D20S
+000107
+
+051200_D..S20180601*D"D24S"D25S"D26S"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000107v"(
+
+051200_D..S20180601*D(:D24S
+000109
+
+051600_D..S20180601*D"D27S"D28S"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000109v"(
+
+051600_D..S20180601*D(:D27S
+000111
+
+052000_D..S20180601*D"D29S"D30S"D31S"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000111v"(
+
+052000_D..S20180601*D(:D29S
+000113
+
+052400_D..S20180601*D"D32S"D33S"D34S"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000113v"(
+
+052400_D..S20180601*D(:D32S
+000115
+
+052800_D..S20180601*D"D35S"D37S"D38S"D39S"D40S"D41S"D42S"D43S3
+000115v"(
+
+052800_D..S20180601*D(:D35S
+000117
+
+053200_D..S20180601*D"D38S"D39S"D40S"D41S"D42S"D43S3
+000117v"(
+
+053200_D..S20180601*D(:D38Sn
+000119d
+
+053600_D..S20180601*D"D41S"D42S"D43S3
+000119v"(
+
+053600_D..S20180601*D(:D41S
+000121
+
+048000_F..N20180601*F"F39N"F38N"F36N"F35N"F34N"F33N"F32N"F31N"F30N"F29N"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000121v"(
+
+048000_F..N20180601*F(:F39N
+000123
+
+048400_F..N20180601*F"F36N"F35N"F34N"F33N"F32N"F31N"F30N"F29N"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000123v"(
+
+048400_F..N20180601*F(:F36N
+000125
+
+048800_F..N20180601*F"F34N"F33N"F32N"F31N"F30N"F29N"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000125v"(
+
+048800_F..N20180601*F(:F34N
+000127
+
+049200_F..N20180601*F"F32N"F31N"F30N"F29N"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000127v"(
+
+049200_F..N20180601*F(:F32N
+000129
+
+049600_F..N20180601*F"F30N"F29N"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000129v"(
+
+049600_F..N20180601*F(:F30N
+000131
+
+050000_F..N20180601*F"F27N"F26N"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000131v"(
+
+050000_F..N20180601*F(:F27N
+000133
+
+050400_F..N20180601*F"F25N"F24N"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000133v"(
+
+050400_F..N20180601*F(:F25N
+000135
+
+050800_F..N20180601*F"F23N"F22N"F21N"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000135v"(
+
+050800_F..N20180601*F(:F23N
+000137
+
+051200_F..N20180601*F"F20N"F18N"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000137v"(
+
+051200_F..N20180601*F(:F20N
+000139
+
+051600_F..N20180601*F"F16N"F15N"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000139v"(
+
+051600_F..N20180601*F(:F16N
+000141
+
+052000_F..N20180601*F"F14N"F12N"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000141v"(
+
+052000_F..N20180601*F(:F14N
+000143
+
+052400_F..N20180601*F"F11N"F09N"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000143v"(
+
+052400_F..N20180601*F(:F11N
+000145
+
+052800_F..N20180601*F"F07N"F06N"F05N"F04N"F03N"F02N"F01N3
+000145v"(
+
+052800_F..N20180601*F(:F07N
+000147
+
+053200_F..N20180601*F"F05N"F04N"F03N"F02N"F01N3
+000147v"(
+
+053200_F..N20180601*F(:F05Nn
+000149d
+
+053600_F..N20180601*F"F03N"F02N"F01N3
+000149v"(
+
+053600_F..N20180601*F(:F03N
+000151
+
+048000_F..S20180601*F"F01S"F02S"F03S"F04S"F05S"F06S"F07S"F09S"F11S"F12S"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000151v"(
+
+048000_F..S20180601*F(:F01S
+000153
+
+048400_F..S20180601*F"F03S"F04S"F05S"F06S"F07S"F09S"F11S"F12S"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000153v"(
+
+048400_F..S20180601*F(:F03S
+000155
+
+048800_F..S20180601*F"F05S"F06S"F07S"F09S"F11S"F12S"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000155v"(
+
+048800_F..S20180601*F(:F05S
+000157
+
+049200_F..S20180601*F"F07S"F09S"F11S"F12S"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000157v"(
+
+049200_F..S20180601*F(:F07S
+000159
+
+049600_F..S20180601*F"F11S"F12S"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000159v"(
+
+049600_F..S20180601*F(:F11S
+000161
+
+050000_F..S20180601*F"F14S"F15S"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000161v"(
+
+050000_F..S20180601*F(:F14S
+000163
+
+050400_F..S20180601*F"F16S"F18S"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000163v"(
+
+050400_F..S20180601*F(:F16S
+000165
+
+050800_F..S20180601*F"F20S"F21S"F22S"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000165v"(
+
+050800_F..S20180601*F(:F20S
+000167
+
+051200_F..S20180601*F"F23S"F24S"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000167v"(
+
+051200_F..S20180601*F(:F23S
+000169
+
+051600_F..S20180601*F"F25S"F26S"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000169v"(
+
+051600_F..S20180601*F(:F25S
+000171
+
+052000_F..S20180601*F"F27S"F29S"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000171v"(
+
+052000_F..S20180601*F(:F27S
+000173
+
+052400_F..S20180601*F"F30S"F31S"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000173v"(
+
+052400_F..S20180601*F(:F30S
+000175
+
+052800_F..S20180601*F"F32S"F33S"F34S"F35S"F36S"F38S"F39S3
+000175v"(
+
+052800_F..S20180601*F(:F32S
+000177
+
+053200_F..S20180601*F"F34S"F35S"F36S"F38S"F39S3
+000177v"(
+
+053200_F..S20180601*F(:F34Sn
+000179d
+
+053600_F..S20180601*F"F36S"F38S"F39S3
+000179v"(
+
+053600_F..S20180601*F(:F36S
\ No newline at end of file
@@ -0,0 +1,365 @@
+
+
+1.0
+
+000001	
+
+048000_A..N20180601*A"A65N"A64N"A63N"A61N"A60N"A59N"A57N"A55N"A54N"A53N"A52N"A51N"A50N"A49N"A48N"A47N"A46N"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27Nځځ"A25N"A24N"A22N"A21N"A20N"A19N"A18NІІ"A17N"A16N"A15Nވވ"A14N"A12N"A11N"A10NƋƋ"A09N"A07N"A06Nԍԍ"A05N"A03N"A02N3
+000001v"(
+
+048000_A..N20180601*A(:A65N	
+000003	
+
+048400_A..N20180601*A"A61N"A60N"A59N"A57N"A55N"A54N"A53N"A52N"A51N"A50N"A49N"A48N"A47N"A46N"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22Nځځ"A21N"A20N"A19N"A18N"A17N"A16N"A15NІІ"A14N"A12N"A11Nވވ"A10N"A09N"A07N"A06NƋƋ"A05N"A03N"A02N3
+000003v"(
+
+048400_A..N20180601*A(:A61N
+000005
+
+048800_A..N20180601*A"A57N"A55N"A54N"A53N"A52N"A51N"A50N"A49N"A48N"A47N"A46N"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19Nځځ"A18N"A17N"A16N"A15N"A14N"A12N"A11NІІ"A10N"A09N"A07Nވވ"A06N"A05N"A03N"A02N3
+000005v"(
+
+048800_A..N20180601*A(:A57N
+000007
+
+049200_A..N20180601*A"A52N"A51N"A50N"A49N"A48N"A47N"A46N"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15Nځځ"A14N"A12N"A11N"A10N"A09N"A07N"A06NІІ"A05N"A03N"A02N3
+000007v"(
+
+049200_A..N20180601*A(:A52N
+000009
+
+049600_A..N20180601*A"A49N"A48N"A47N"A46N"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11Nځځ"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000009v"(
+
+049600_A..N20180601*A(:A49N
+000011
+
+050000_A..N20180601*A"A45N"A44N"A43N"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06Nځځ"A05N"A03N"A02N3
+000011v"(
+
+050000_A..N20180601*A(:A45N
+000013
+
+050400_A..N20180601*A"A42N"A41N"A40N"A38N"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000013v"(
+
+050400_A..N20180601*A(:A42N
+000015
+
+050800_A..N20180601*A"A36N"A34N"A33N"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000015v"(
+
+050800_A..N20180601*A(:A36N
+000017
+
+051200_A..N20180601*A"A32N"A31N"A30N"A28N"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000017v"(
+
+051200_A..N20180601*A(:A32N
+000019
+
+051600_A..N20180601*A"A27N"A25N"A24N"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000019v"(
+
+051600_A..N20180601*A(:A27N
+000021
+
+052000_A..N20180601*A"A22N"A21N"A20N"A19N"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000021v"(
+
+052000_A..N20180601*A(:A22N
+000023
+
+052400_A..N20180601*A"A18N"A17N"A16N"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000023v"(
+
+052400_A..N20180601*A(:A18N
+000025
+
+052800_A..N20180601*A"A15N"A14N"A12N"A11N"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000025v"(
+
+052800_A..N20180601*A(:A15N
+000027
+
+053200_A..N20180601*A"A10N"A09N"A07N"A06N"A05N"A03N"A02N3
+000027v"(
+
+053200_A..N20180601*A(:A10N
+000029|
+
+053600_A..N20180601*A"A06N"A05N"A03N"A02N3
+000029v"(
+
+053600_A..N20180601*A(:A06N
+
+000031	
+
+048000_A..S20180601*A"A02S"A03S"A05S"A06S"A07S"A09S"A10S"A11S"A12S"A14S"A15S"A16S"A17S"A18S"A19S"A20S"A21S"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42Sځځ"A43S"A44S"A45S"A46S"A47S"A48S"A49SІІ"A50S"A51S"A52Sވވ"A53S"A54S"A55S"A57SƋƋ"A59S"A60S"A61Sԍԍ"A63S"A64S"A65S3
+000031v"(
+
+048000_A..S20180601*A(:A02S	
+000033	
+
+048400_A..S20180601*A"A06S"A07S"A09S"A10S"A11S"A12S"A14S"A15S"A16S"A17S"A18S"A19S"A20S"A21S"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45Sځځ"A46S"A47S"A48S"A49S"A50S"A51S"A52SІІ"A53S"A54S"A55Sވވ"A57S"A59S"A60S"A61SƋƋ"A63S"A64S"A65S3
+000033v"(
+
+048400_A..S20180601*A(:A06S
+000035
+
+048800_A..S20180601*A"A10S"A11S"A12S"A14S"A15S"A16S"A17S"A18S"A19S"A20S"A21S"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48Sځځ"A49S"A50S"A51S"A52S"A53S"A54S"A55SІІ"A57S"A59S"A60Sވވ"A61S"A63S"A64S"A65S3
+000035v"(
+
+048800_A..S20180601*A(:A10S
+000037
+
+049200_A..S20180601*A"A15S"A16S"A17S"A18S"A19S"A20S"A21S"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52Sځځ"A53S"A54S"A55S"A57S"A59S"A60S"A61SІІ"A63S"A64S"A65S3
+000037v"(
+
+049200_A..S20180601*A(:A15S
+000039
+
+049600_A..S20180601*A"A18S"A19S"A20S"A21S"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55Sځځ"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000039v"(
+
+049600_A..S20180601*A(:A18S
+000041
+
+050000_A..S20180601*A"A22S"A24S"A25S"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61Sځځ"A63S"A64S"A65S3
+000041v"(
+
+050000_A..S20180601*A(:A22S
+000043
+
+050400_A..S20180601*A"A27S"A28S"A30S"A31S"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000043v"(
+
+050400_A..S20180601*A(:A27S
+000045
+
+050800_A..S20180601*A"A32S"A33S"A34S"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000045v"(
+
+050800_A..S20180601*A(:A32S
+000047
+
+051200_A..S20180601*A"A36S"A38S"A40S"A41S"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000047v"(
+
+051200_A..S20180601*A(:A36S
+000049
+
+051600_A..S20180601*A"A42S"A43S"A44S"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000049v"(
+
+051600_A..S20180601*A(:A42S
+000051
+
+052000_A..S20180601*A"A45S"A46S"A47S"A48S"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000051v"(
+
+052000_A..S20180601*A(:A45S
+000053
+
+052400_A..S20180601*A"A49S"A50S"A51S"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000053v"(
+
+052400_A..S20180601*A(:A49S
+000055
+
+052800_A..S20180601*A"A52S"A53S"A54S"A55S"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000055v"(
+
+052800_A..S20180601*A(:A52S
+000057
+
+053200_A..S20180601*A"A57S"A59S"A60S"A61S"A63S"A64S"A65S3
+000057v"(
+
+053200_A..S20180601*A(:A57S
+000059|
+
+053600_A..S20180601*A"A61S"A63S"A64S"A65S3
+000059v"(
+
+053600_A..S20180601*A(:A61S
+000061
+
+048000_H..N20180601*H"H19N"H15N"H14N"H13N"H12N"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000061v"(
+
+048000_H..N20180601*H(:H19N
+000063
+
+048400_H..N20180601*H"H15N"H14N"H13N"H12N"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000063v"(
+
+048400_H..N20180601*H(:H15N
+000065
+
+048800_H..N20180601*H"H14N"H13N"H12N"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000065v"(
+
+048800_H..N20180601*H(:H14N
+000067
+
+049200_H..N20180601*H"H13N"H12N"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000067v"(
+
+049200_H..N20180601*H(:H13N
+000069
+
+049600_H..N20180601*H"H12N"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000069v"(
+
+049600_H..N20180601*H(:H12N
+000071
+
+050000_H..N20180601*H"H11N"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000071v"(
+
+050000_H..N20180601*H(:H11N
+000073
+
+050400_H..N20180601*H"H10N"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000073v"(
+
+050400_H..N20180601*H(:H10N
+000075
+
+050800_H..N20180601*H"H09N"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000075v"(
+
+050800_H..N20180601*H(:H09N
+000077
+
+051200_H..N20180601*H"H08N"H07N"H06N"H04N"H03N"H02N"H01N3
+000077v"(
+
+051200_H..N20180601*H(:H08N
+000079
+
+051600_H..N20180601*H"H07N"H06N"H04N"H03N"H02N"H01N3
+000079v"(
+
+051600_H..N20180601*H(:H07N
+000081
+
+052000_H..N20180601*H"H06N"H04N"H03N"H02N"H01N3
+000081v"(
+
+052000_H..N20180601*H(:H06N
+000083|
+
+052400_H..N20180601*H"H04N"H03N"H02N"H01N3
+000083v"(
+
+052400_H..N20180601*H(:H04Nn
+000085d
+
+052800_H..N20180601*H"H03N"H02N"H01N3
+000085v"(
+
+052800_H..N20180601*H(:H03NV
+000087L
+
+053200_H..N20180601*H"H02N"H01N3
+000087v"(
+
+053200_H..N20180601*H(:H02N>
+0000894
+
+053600_H..N20180601*H"H01N3
+000089v"(
+
+053600_H..N20180601*H(:H01N
+000091
+
+048000_H..S20180601*H"H01S"H02S"H03S"H04S"H06S"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000091v"(
+
+048000_H..S20180601*H(:H01S
+000093
+
+048400_H..S20180601*H"H02S"H03S"H04S"H06S"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000093v"(
+
+048400_H..S20180601*H(:H02S
+000095
+
+048800_H..S20180601*H"H03S"H04S"H06S"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000095v"(
+
+048800_H..S20180601*H(:H03S
+000097
+
+049200_H..S20180601*H"H04S"H06S"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000097v"(
+
+049200_H..S20180601*H(:H04S
+000099
+
+049600_H..S20180601*H"H06S"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000099v"(
+
+049600_H..S20180601*H(:H06S
+000101
+
+050000_H..S20180601*H"H07S"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000101v"(
+
+050000_H..S20180601*H(:H07S
+000103
+
+050400_H..S20180601*H"H08S"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000103v"(
+
+050400_H..S20180601*H(:H08S
+000105
+
+050800_H..S20180601*H"H09S"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000105v"(
+
+050800_H..S20180601*H(:H09S
+000107
+
+051200_H..S20180601*H"H10S"H11S"H12S"H13S"H14S"H15S"H19S3
+000107v"(
+
+051200_H..S20180601*H(:H10S
+000109
+
+051600_H..S20180601*H"H11S"H12S"H13S"H14S"H15S"H19S3
+000109v"(
+
+051600_H..S20180601*H(:H11S
+000111
+
+052000_H..S20180601*H"H12S"H13S"H14S"H15S"H19S3
+000111v"(
+
+052000_H..S20180601*H(:H12S
+000113|
+
+052400_H..S20180601*H"H13S"H14S"H15S"H19S3
+000113v"(
+
+052400_H..S20180601*H(:H13Sn
+000115d
+
+052800_H..S20180601*H"H14S"H15S"H19S3
+000115v"(
+
+052800_H..S20180601*H(:H14SV
+000117L
+
+053200_H..S20180601*H"H15S"H19S3
+000117v"(
+
+053200_H..S20180601*H(:H15S>
+0001194
+
+053600_H..S20180601*H"H19S3
+000119v"(
+
+053600_H..S20180601*H(:H19S
\ No newline at end of file
@@ -0,0 +1,183 @@
+
+
+1.0
+000001
+
+048000_G..N20180601*G"G36N"G35N"G34N"G33N"G32N"G31N"G30N"G29N"G28N"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000001v"(
+
+048000_G..N20180601*G(:G36N
+000003
+
+048400_G..N20180601*G"G35N"G34N"G33N"G32N"G31N"G30N"G29N"G28N"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000003v"(
+
+048400_G..N20180601*G(:G35N
+000005
+
+048800_G..N20180601*G"G33N"G32N"G31N"G30N"G29N"G28N"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000005v"(
+
+048800_G..N20180601*G(:G33N
+000007
+
+049200_G..N20180601*G"G31N"G30N"G29N"G28N"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000007v"(
+
+049200_G..N20180601*G(:G31N
+000009
+
+049600_G..N20180601*G"G29N"G28N"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000009v"(
+
+049600_G..N20180601*G(:G29N
+000011
+
+050000_G..N20180601*G"G26N"G24N"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000011v"(
+
+050000_G..N20180601*G(:G26N
+000013
+
+050400_G..N20180601*G"G22N"G21N"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000013v"(
+
+050400_G..N20180601*G(:G22N
+000015
+
+050800_G..N20180601*G"G20N"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000015v"(
+
+050800_G..N20180601*G(:G20N
+000017
+
+051200_G..N20180601*G"G19N"G18N"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000017v"(
+
+051200_G..N20180601*G(:G19N
+000019
+
+051600_G..N20180601*G"G16N"G15N"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000019v"(
+
+051600_G..N20180601*G(:G16N
+000021
+
+052000_G..N20180601*G"G14N"G13N"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000021v"(
+
+052000_G..N20180601*G(:G14N
+000023
+
+052400_G..N20180601*G"G12N"G11N"G10N"G09N"G08N"G07N"G06N"G05N3
+000023v"(
+
+052400_G..N20180601*G(:G12N
+000025
+
+052800_G..N20180601*G"G10N"G09N"G08N"G07N"G06N"G05N3
+000025v"(
+
+052800_G..N20180601*G(:G10N
+000027|
+
+053200_G..N20180601*G"G08N"G07N"G06N"G05N3
+000027v"(
+
+053200_G..N20180601*G(:G08NV
+000029L
+
+053600_G..N20180601*G"G06N"G05N3
+000029v"(
+
+053600_G..N20180601*G(:G06N
+000031
+
+048000_G..S20180601*G"G05S"G06S"G07S"G08S"G09S"G10S"G11S"G12S"G13S"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000031v"(
+
+048000_G..S20180601*G(:G05S
+000033
+
+048400_G..S20180601*G"G06S"G07S"G08S"G09S"G10S"G11S"G12S"G13S"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000033v"(
+
+048400_G..S20180601*G(:G06S
+000035
+
+048800_G..S20180601*G"G08S"G09S"G10S"G11S"G12S"G13S"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000035v"(
+
+048800_G..S20180601*G(:G08S
+000037
+
+049200_G..S20180601*G"G10S"G11S"G12S"G13S"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000037v"(
+
+049200_G..S20180601*G(:G10S
+000039
+
+049600_G..S20180601*G"G12S"G13S"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000039v"(
+
+049600_G..S20180601*G(:G12S
+000041
+
+050000_G..S20180601*G"G14S"G15S"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000041v"(
+
+050000_G..S20180601*G(:G14S
+000043
+
+050400_G..S20180601*G"G16S"G18S"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000043v"(
+
+050400_G..S20180601*G(:G16S
+000045
+
+050800_G..S20180601*G"G19S"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000045v"(
+
+050800_G..S20180601*G(:G19S
+000047
+
+051200_G..S20180601*G"G20S"G21S"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000047v"(
+
+051200_G..S20180601*G(:G20S
+000049
+
+051600_G..S20180601*G"G22S"G24S"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000049v"(
+
+051600_G..S20180601*G(:G22S
+000051
+
+052000_G..S20180601*G"G26S"G28S"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000051v"(
+
+052000_G..S20180601*G(:G26S
+000053
+
+052400_G..S20180601*G"G29S"G30S"G31S"G32S"G33S"G34S"G35S"G36S3
+000053v"(
+
+052400_G..S20180601*G(:G29S
+000055
+
+052800_G..S20180601*G"G31S"G32S"G33S"G34S"G35S"G36S3
+000055v"(
+
+052800_G..S20180601*G(:G31S
+000057|
+
+053200_G..S20180601*G"G33S"G34S"G35S"G36S3
+000057v"(
+
+053200_G..S20180601*G(:G33SV
+000059L
+
+053600_G..S20180601*G"G35S"G36S3
+000059v"(
+
+053600_G..S20180601*G(:G35S
\ No newline at end of file
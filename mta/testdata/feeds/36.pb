@@ -0,0 +1,363 @@
+
+
+1.0
+000001
+
+048000_J..N20180601*J"J31N"J30N"J29N"J28N"J27N"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000001v"(
+
+048000_J..N20180601*J(:J31N
+000003
+
+048400_J..N20180601*J"J30N"J29N"J28N"J27N"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000003v"(
+
+048400_J..N20180601*J(:J30N
+000005
+
+048800_J..N20180601*J"J29N"J28N"J27N"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000005v"(
+
+048800_J..N20180601*J(:J29N
+000007
+
+049200_J..N20180601*J"J28N"J27N"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000007v"(
+
+049200_J..N20180601*J(:J28N
+000009
+
+049600_J..N20180601*J"J27N"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000009v"(
+
+049600_J..N20180601*J(:J27N
+000011
+
+050000_J..N20180601*J"J24N"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000011v"(
+
+050000_J..N20180601*J(:J24N
+000013
+
+050400_J..N20180601*J"J23N"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000013v"(
+
+050400_J..N20180601*J(:J23N
+000015
+
+050800_J..N20180601*J"J22N"J21N"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000015v"(
+
+050800_J..N20180601*J(:J22N
+000017
+
+051200_J..N20180601*J"J20N"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000017v"(
+
+051200_J..N20180601*J(:J20N
+000019
+
+051600_J..N20180601*J"J19N"J17N"J16N"J15N"J14N"J13N"J12N3
+000019v"(
+
+051600_J..N20180601*J(:J19N
+000021
+
+052000_J..N20180601*J"J17N"J16N"J15N"J14N"J13N"J12N3
+000021v"(
+
+052000_J..N20180601*J(:J17N
+000023
+
+052400_J..N20180601*J"J16N"J15N"J14N"J13N"J12N3
+000023v"(
+
+052400_J..N20180601*J(:J16N
+000025|
+
+052800_J..N20180601*J"J15N"J14N"J13N"J12N3
+000025v"(
+
+052800_J..N20180601*J(:J15Nn
+000027d
+
+053200_J..N20180601*J"J14N"J13N"J12N3
+000027v"(
+
+053200_J..N20180601*J(:J14NV
+000029L
+
+053600_J..N20180601*J"J13N"J12N3
+000029v"(
+
+053600_J..N20180601*J(:J13N
+000031
+
+048000_J..S20180601*J"J12S"J13S"J14S"J15S"J16S"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000031v"(
+
+048000_J..S20180601*J(:J12S
+000033
+
+048400_J..S20180601*J"J13S"J14S"J15S"J16S"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000033v"(
+
+048400_J..S20180601*J(:J13S
+000035
+
+048800_J..S20180601*J"J14S"J15S"J16S"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000035v"(
+
+048800_J..S20180601*J(:J14S
+000037
+
+049200_J..S20180601*J"J15S"J16S"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000037v"(
+
+049200_J..S20180601*J(:J15S
+000039
+
+049600_J..S20180601*J"J16S"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000039v"(
+
+049600_J..S20180601*J(:J16S
+000041
+
+050000_J..S20180601*J"J17S"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000041v"(
+
+050000_J..S20180601*J(:J17S
+000043
+
+050400_J..S20180601*J"J19S"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000043v"(
+
+050400_J..S20180601*J(:J19S
+000045
+
+050800_J..S20180601*J"J20S"J21S"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000045v"(
+
+050800_J..S20180601*J(:J20S
+000047
+
+051200_J..S20180601*J"J22S"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000047v"(
+
+051200_J..S20180601*J(:J22S
+000049
+
+051600_J..S20180601*J"J23S"J24S"J27S"J28S"J29S"J30S"J31S3
+000049v"(
+
+051600_J..S20180601*J(:J23S
+000051
+
+052000_J..S20180601*J"J24S"J27S"J28S"J29S"J30S"J31S3
+000051v"(
+
+052000_J..S20180601*J(:J24S
+000053
+
+052400_J..S20180601*J"J27S"J28S"J29S"J30S"J31S3
+000053v"(
+
+052400_J..S20180601*J(:J27S
+000055|
+
+052800_J..S20180601*J"J28S"J29S"J30S"J31S3
+000055v"(
+
+052800_J..S20180601*J(:J28Sn
+000057d
+
+053200_J..S20180601*J"J29S"J30S"J31S3
+000057v"(
+
+053200_J..S20180601*J(:J29SV
+000059L
+
+053600_J..S20180601*J"J30S"J31S3
+000059v"(
+
+053600_J..S20180601*J(:J30S
+000061
+
+048000_M..N20180601*M"M23N"M22N"M21N"M20N"M19N"M18N"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000061v"(
+
+048000_M..N20180601*M(:M23N
+000063
+
+048400_M..N20180601*M"M22N"M21N"M20N"M19N"M18N"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000063v"(
+
+048400_M..N20180601*M(:M22N
+000065
+
+048800_M..N20180601*M"M21N"M20N"M19N"M18N"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000065v"(
+
+048800_M..N20180601*M(:M21N
+000067
+
+049200_M..N20180601*M"M20N"M19N"M18N"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000067v"(
+
+049200_M..N20180601*M(:M20N
+000069
+
+049600_M..N20180601*M"M19N"M18N"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000069v"(
+
+049600_M..N20180601*M(:M19N
+000071
+
+050000_M..N20180601*M"M16N"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000071v"(
+
+050000_M..N20180601*M(:M16N
+000073
+
+050400_M..N20180601*M"M14N"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000073v"(
+
+050400_M..N20180601*M(:M14N
+000075
+
+050800_M..N20180601*M"M13N"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000075v"(
+
+050800_M..N20180601*M(:M13N
+000077
+
+051200_M..N20180601*M"M12N"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000077v"(
+
+051200_M..N20180601*M(:M12N
+000079
+
+051600_M..N20180601*M"M11N"M10N"M09N"M08N"M06N"M05N"M04N"M01N3
+000079v"(
+
+051600_M..N20180601*M(:M11N
+000081
+
+052000_M..N20180601*M"M09N"M08N"M06N"M05N"M04N"M01N3
+000081v"(
+
+052000_M..N20180601*M(:M09N
+000083
+
+052400_M..N20180601*M"M08N"M06N"M05N"M04N"M01N3
+000083v"(
+
+052400_M..N20180601*M(:M08N
+000085|
+
+052800_M..N20180601*M"M06N"M05N"M04N"M01N3
+000085v"(
+
+052800_M..N20180601*M(:M06Nn
+000087d
+
+053200_M..N20180601*M"M05N"M04N"M01N3
+000087v"(
+
+053200_M..N20180601*M(:M05NV
+000089L
+
+053600_M..N20180601*M"M04N"M01N3
+000089v"(
+
+053600_M..N20180601*M(:M04N
+000091
+
+048000_M..S20180601*M"M01S"M04S"M05S"M06S"M08S"M09S"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000091v"(
+
+048000_M..S20180601*M(:M01S
+000093
+
+048400_M..S20180601*M"M04S"M05S"M06S"M08S"M09S"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000093v"(
+
+048400_M..S20180601*M(:M04S
+000095
+
+048800_M..S20180601*M"M05S"M06S"M08S"M09S"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000095v"(
+
+048800_M..S20180601*M(:M05S
+000097
+
+049200_M..S20180601*M"M06S"M08S"M09S"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000097v"(
+
+049200_M..S20180601*M(:M06S
+000099
+
+049600_M..S20180601*M"M08S"M09S"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000099v"(
+
+049600_M..S20180601*M(:M08S
+000101
+
+050000_M..S20180601*M"M10S"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000101v"(
+
+050000_M..S20180601*M(:M10S
+000103
+
+050400_M..S20180601*M"M11S"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000103v"(
+
+050400_M..S20180601*M(:M11S
+000105
+
+050800_M..S20180601*M"M12S"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000105v"(
+
+050800_M..S20180601*M(:M12S
+000107
+
+051200_M..S20180601*M"M13S"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000107v"(
+
+051200_M..S20180601*M(:M13S
+000109
+
+051600_M..S20180601*M"M14S"M16S"M18S"M19S"M20S"M21S"M22S"M23S3
+000109v"(
+
+051600_M..S20180601*M(:M14S
+000111
+
+052000_M..S20180601*M"M18S"M19S"M20S"M21S"M22S"M23S3
+000111v"(
+
+052000_M..S20180601*M(:M18S
+000113
+
+052400_M..S20180601*M"M19S"M20S"M21S"M22S"M23S3
+000113v"(
+
+052400_M..S20180601*M(:M19S
+000115|
+
+052800_M..S20180601*M"M20S"M21S"M22S"M23S3
+000115v"(
+
+052800_M..S20180601*M(:M20Sn
+000117d
+
+053200_M..S20180601*M"M21S"M22S"M23S3
+000117v"(
+
+053200_M..S20180601*M(:M21SV
+000119L
+
+053600_M..S20180601*M"M22S"M23S3
+000119v"(
+
+053600_M..S20180601*M(:M22S
\ No newline at end of file
@@ -0,0 +1,183 @@
+
+
+1.0
+000001
+
+048000_7..N20180601*7"726N"725N"724N"723N"721N"720N"719N"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000001v"(
+
+048000_7..N20180601*7(:726N
+000003
+
+048400_7..N20180601*7"725N"724N"723N"721N"720N"719N"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000003v"(
+
+048400_7..N20180601*7(:725N
+000005
+
+048800_7..N20180601*7"724N"723N"721N"720N"719N"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000005v"(
+
+048800_7..N20180601*7(:724N
+000007
+
+049200_7..N20180601*7"721N"720N"719N"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000007v"(
+
+049200_7..N20180601*7(:721N
+000009
+
+049600_7..N20180601*7"720N"719N"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000009v"(
+
+049600_7..N20180601*7(:720N
+000011
+
+050000_7..N20180601*7"718N"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000011v"(
+
+050000_7..N20180601*7(:718N
+000013
+
+050400_7..N20180601*7"716N"715N"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000013v"(
+
+050400_7..N20180601*7(:716N
+000015
+
+050800_7..N20180601*7"714N"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000015v"(
+
+050800_7..N20180601*7(:714N
+000017
+
+051200_7..N20180601*7"713N"712N"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000017v"(
+
+051200_7..N20180601*7(:713N
+000019
+
+051600_7..N20180601*7"711N"710N"709N"708N"707N"706N"705N"702N"701N3
+000019v"(
+
+051600_7..N20180601*7(:711N
+000021
+
+052000_7..N20180601*7"710N"709N"708N"707N"706N"705N"702N"701N3
+000021v"(
+
+052000_7..N20180601*7(:710N
+000023
+
+052400_7..N20180601*7"708N"707N"706N"705N"702N"701N3
+000023v"(
+
+052400_7..N20180601*7(:708N
+000025
+
+052800_7..N20180601*7"707N"706N"705N"702N"701N3
+000025v"(
+
+052800_7..N20180601*7(:707Nn
+000027d
+
+053200_7..N20180601*7"705N"702N"701N3
+000027v"(
+
+053200_7..N20180601*7(:705NV
+000029L
+
+053600_7..N20180601*7"702N"701N3
+000029v"(
+
+053600_7..N20180601*7(:702N
+000031
+
+048000_7..S20180601*7"701S"702S"705S"706S"707S"708S"709S"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000031v"(
+
+048000_7..S20180601*7(:701S
+000033
+
+048400_7..S20180601*7"702S"705S"706S"707S"708S"709S"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000033v"(
+
+048400_7..S20180601*7(:702S
+000035
+
+048800_7..S20180601*7"705S"706S"707S"708S"709S"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000035v"(
+
+048800_7..S20180601*7(:705S
+000037
+
+049200_7..S20180601*7"707S"708S"709S"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000037v"(
+
+049200_7..S20180601*7(:707S
+000039
+
+049600_7..S20180601*7"708S"709S"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000039v"(
+
+049600_7..S20180601*7(:708S
+000041
+
+050000_7..S20180601*7"710S"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000041v"(
+
+050000_7..S20180601*7(:710S
+000043
+
+050400_7..S20180601*7"711S"712S"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000043v"(
+
+050400_7..S20180601*7(:711S
+000045
+
+050800_7..S20180601*7"713S"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000045v"(
+
+050800_7..S20180601*7(:713S
+000047
+
+051200_7..S20180601*7"714S"715S"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000047v"(
+
+051200_7..S20180601*7(:714S
+000049
+
+051600_7..S20180601*7"716S"718S"719S"720S"721S"723S"724S"725S"726S3
+000049v"(
+
+051600_7..S20180601*7(:716S
+000051
+
+052000_7..S20180601*7"718S"719S"720S"721S"723S"724S"725S"726S3
+000051v"(
+
+052000_7..S20180601*7(:718S
+000053
+
+052400_7..S20180601*7"720S"721S"723S"724S"725S"726S3
+000053v"(
+
+052400_7..S20180601*7(:720S
+000055
+
+052800_7..S20180601*7"721S"723S"724S"725S"726S3
+000055v"(
+
+052800_7..S20180601*7(:721Sn
+000057d
+
+053200_7..S20180601*7"724S"725S"726S3
+000057v"(
+
+053200_7..S20180601*7(:724SV
+000059L
+
+053600_7..S20180601*7"725S"726S3
+000059v"(
+
+053600_7..S20180601*7(:725S
\ No newline at end of file
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("requests_total", "Requests by method.", "method", "code")
+	g := r.NewGaugeVec("temperature", "Temperature\nin \\degrees.")
+	h := r.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "path")
+
+	c.Inc("GET", "200")
+	c.Add(2, "GET", "200")
+	c.Inc(`P"OST`, "500")
+	g.Set(-1.5)
+	h.Observe(0.05, "/")
+	h.Observe(0.5, "/")
+	h.Observe(3, "/")
+
+	want := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{path="/",le="0.1"} 1
+latency_seconds_bucket{path="/",le="1"} 2
+latency_seconds_bucket{path="/",le="+Inf"} 3
+latency_seconds_sum{path="/"} 3.55
+latency_seconds_count{path="/"} 3
+# HELP requests_total Requests by method.
+# TYPE requests_total counter
+requests_total{method="GET",code="200"} 3
+requests_total{method="P\"OST",code="500"} 1
+# HELP temperature Temperature\nin \\degrees.
+# TYPE temperature gauge
+temperature -1.5
+`
+	var b bytes.Buffer
+	if err := r.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering a name twice did not panic")
+		}
+	}()
+	r := NewRegistry()
+	r.NewCounterVec("a", "")
+	r.NewGaugeVec("a", "")
+}
@@ -0,0 +1,186 @@
+// Package pushtest provides a stand-in for a Web Push service and the
+// browsers subscribed to it, which verifies and decrypts the messages
+// pushed, for tests.
+package pushtest
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/webpush"
+)
+
+// PushPath is the path endpoints are under.
+const PushPath = "/push/"
+
+// Message is a message delivered to a subscription.
+type Message struct {
+	Payload []byte
+	TTL     int
+	Topic   string
+	Urgency string
+	// Subject is the contact of the application server that pushed it.
+	Subject string
+}
+
+// subscription is a browser's subscription.
+type subscription struct {
+	// applicationServerKey is the only key allowed to push to it.
+	applicationServerKey string
+	key                  *ecdsa.PrivateKey
+	auth                 []byte
+	messages             []*Message
+	// gone is set once the browser unsubscribes.
+	gone bool
+	// fail is the status code pushes fail with, if set.
+	fail int
+}
+
+// Server accepts pushes to its subscriptions as a push service does:
+// pushes to unknown endpoints are not found, to unsubscribed endpoints
+// gone, and pushes not authenticated by the subscription's application
+// server key or that fail to decrypt are rejected.
+type Server struct {
+	*httptest.Server
+
+	mtx  sync.Mutex
+	subs map[string]*subscription
+}
+
+// NewServer starts a push service with no subscriptions, served over TLS;
+// its Client trusts the certificate.
+func NewServer() *Server {
+	s := &Server{subs: make(map[string]*subscription)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PushPath, s.servePush)
+	// Push services are served over HTTPS.
+	s.Server = httptest.NewTLSServer(mux)
+	return s
+}
+
+// Subscribe subscribes a new browser to pushes by the application server
+// with the given public key, as PushManager.subscribe does.
+func (s *Server) Subscribe(applicationServerKey string) (*webpush.Subscription, error) {
+	key, err := webpush.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	auth := make([]byte, 16)
+	id := make([]byte, 16)
+	for _, b := range [][]byte{auth, id} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+	sub := &webpush.Subscription{
+		Endpoint: s.URL + PushPath + base64.RawURLEncoding.EncodeToString(id),
+		Keys: webpush.Keys{
+			P256dh: webpush.EncodePublicKey(&key.PublicKey),
+			Auth:   base64.RawURLEncoding.EncodeToString(auth),
+		},
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.subs[sub.Endpoint] = &subscription{applicationServerKey: applicationServerKey, key: key, auth: auth}
+	return sub, nil
+}
+
+// Unsubscribe unsubscribes the browser of an endpoint, so that pushes to
+// it are gone.
+func (s *Server) Unsubscribe(endpoint string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sub, ok := s.subs[endpoint]; ok {
+		sub.gone = true
+	}
+}
+
+// Fail makes pushes to an endpoint fail with the status code, or succeed
+// again with a code of zero.
+func (s *Server) Fail(endpoint string, code int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sub, ok := s.subs[endpoint]; ok {
+		sub.fail = code
+	}
+}
+
+// Messages returns the messages delivered to an endpoint, in order.
+func (s *Server) Messages(endpoint string) []*Message {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub, ok := s.subs[endpoint]
+	if !ok {
+		return nil
+	}
+	return append([]*Message(nil), sub.messages...)
+}
+
+func (s *Server) servePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	endpoint := s.URL + r.URL.Path
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub, ok := s.subs[endpoint]
+	switch {
+	case !ok:
+		http.NotFound(w, r)
+		return
+	case sub.gone:
+		http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+		return
+	case sub.fail != 0:
+		http.Error(w, http.StatusText(sub.fail), sub.fail)
+		return
+	}
+
+	key, subject, err := webpush.VerifyAuthorization(r.Header.Get("Authorization"), endpoint, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if key != sub.applicationServerKey {
+		http.Error(w, "key does not match subscription", http.StatusForbidden)
+		return
+	}
+	ttl, err := strconv.Atoi(r.Header.Get("TTL"))
+	if err != nil || ttl < 0 {
+		http.Error(w, "invalid TTL", http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get("Content-Encoding") != "aes128gcm" {
+		http.Error(w, "unsupported content encoding", http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, 4096))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	payload, err := webpush.Decrypt(sub.key, sub.auth, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	msg := &Message{
+		Payload: payload,
+		TTL:     ttl,
+		Topic:   r.Header.Get("Topic"),
+		Urgency: r.Header.Get("Urgency"),
+		Subject: subject,
+	}
+	sub.messages = append(sub.messages, msg)
+	w.Header().Set("Location", endpoint+"/"+strconv.Itoa(len(sub.messages)))
+	w.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,131 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxExpiry is the furthest in the future a VAPID token may expire.
+const maxExpiry = 24 * time.Hour
+
+var jwtHeader = encoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+
+// VAPID identifies an application server to push services.
+type VAPID struct {
+	Key *ecdsa.PrivateKey
+	// Subject is a mailto: or https: URL push services may contact the
+	// operator of the application server at.
+	Subject string
+}
+
+type claims struct {
+	Aud string `json:"aud"`
+	Exp int64  `json:"exp"`
+	Sub string `json:"sub,omitempty"`
+}
+
+// Authorization returns the Authorization header of a push to an
+// endpoint, valid until exp.
+func (v *VAPID) Authorization(endpoint string, exp time.Time) (string, error) {
+	aud, err := audience(endpoint)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(claims{Aud: aud, Exp: exp.Unix(), Sub: v.Subject})
+	if err != nil {
+		return "", err
+	}
+	signed := jwtHeader + "." + encoding.EncodeToString(b)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, v.Key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	sig := append(pad(r.Bytes()), pad(s.Bytes())...)
+	token := signed + "." + encoding.EncodeToString(sig)
+	return "vapid t=" + token + ", k=" + EncodePublicKey(&v.Key.PublicKey), nil
+}
+
+// VerifyAuthorization verifies the Authorization header of a push to an
+// endpoint at the given time, as a push service would, returning the
+// application server's public key and subject.
+func VerifyAuthorization(header, endpoint string, now time.Time) (string, string, error) {
+	if !strings.HasPrefix(header, "vapid ") {
+		return "", "", errors.New("webpush: not a vapid authorization")
+	}
+	var token, key string
+	for _, param := range strings.Split(header[len("vapid "):], ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			token = kv[1]
+		case "k":
+			key = kv[1]
+		}
+	}
+	public, err := decodeBase64(key)
+	if err != nil {
+		return "", "", errors.New("webpush: invalid key")
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), public)
+	if x == nil {
+		return "", "", errors.New("webpush: invalid key")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", "", errors.New("webpush: invalid token")
+	}
+	sig, err := decodeBase64(parts[2])
+	if err != nil || len(sig) != 64 {
+		return "", "", errors.New("webpush: invalid signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	if !ecdsa.Verify(pub, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+		return "", "", errors.New("webpush: invalid signature")
+	}
+
+	b, err := decodeBase64(parts[1])
+	if err != nil {
+		return "", "", errors.New("webpush: invalid claims")
+	}
+	var c claims
+	if err := json.Unmarshal(b, &c); err != nil {
+		return "", "", errors.New("webpush: invalid claims")
+	}
+	aud, err := audience(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+	switch exp := time.Unix(c.Exp, 0); {
+	case c.Aud != aud:
+		return "", "", errors.Errorf("webpush: audience %q, want %q", c.Aud, aud)
+	case !exp.After(now):
+		return "", "", errors.New("webpush: token expired")
+	case exp.After(now.Add(maxExpiry)):
+		return "", "", errors.New("webpush: token expires too late")
+	}
+	return EncodePublicKey(pub), c.Sub, nil
+}
+
+// audience returns the origin of an endpoint.
+func audience(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.Errorf("webpush: invalid endpoint %q", endpoint)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
@@ -0,0 +1,245 @@
+// Package webpush encrypts Web Push messages (RFC 8291) and authenticates
+// the application server sending them with VAPID (RFC 8292).
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// recordSize is the record size of encrypted messages, which are
+	// written as a single record.
+	recordSize = 4096
+	saltLen    = 16
+	authLen    = 16
+	keyLen     = 65
+	headerLen  = saltLen + 4 + 1 + keyLen
+)
+
+var encoding = base64.RawURLEncoding
+
+// Subscription is a push subscription as serialized by the browser's
+// PushSubscription.toJSON.
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     Keys   `json:"keys"`
+}
+
+// Keys are the keys of a push subscription, in unpadded base64url.
+type Keys struct {
+	// P256dh is the user agent's public key.
+	P256dh string `json:"p256dh"`
+	// Auth is the authentication secret.
+	Auth string `json:"auth"`
+}
+
+// decode returns the user agent's public key and authentication secret.
+func (k Keys) decode() ([]byte, []byte, error) {
+	public, err := decodeBase64(k.P256dh)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "webpush: invalid p256dh")
+	}
+	if x, _ := elliptic.Unmarshal(elliptic.P256(), public); x == nil {
+		return nil, nil, errors.New("webpush: invalid p256dh")
+	}
+	auth, err := decodeBase64(k.Auth)
+	if err != nil || len(auth) != authLen {
+		return nil, nil, errors.New("webpush: invalid auth")
+	}
+	return public, auth, nil
+}
+
+// Validate reports whether the keys of a subscription are well formed.
+func (s *Subscription) Validate() error {
+	_, _, err := s.Keys.decode()
+	return err
+}
+
+// decodeBase64 decodes base64url, padded or not, as browsers vary.
+func decodeBase64(s string) ([]byte, error) {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return encoding.DecodeString(s)
+}
+
+// GenerateKey returns a new P-256 key, for VAPID or a user agent.
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// EncodePrivateKey returns a private key in unpadded base64url.
+func EncodePrivateKey(k *ecdsa.PrivateKey) string {
+	return encoding.EncodeToString(pad(k.D.Bytes()))
+}
+
+// DecodePrivateKey decodes a private key encoded by EncodePrivateKey.
+func DecodePrivateKey(s string) (*ecdsa.PrivateKey, error) {
+	b, err := decodeBase64(s)
+	if err != nil || len(b) != 32 {
+		return nil, errors.New("webpush: invalid private key")
+	}
+	curve := elliptic.P256()
+	k := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(b)}
+	if k.D.Sign() == 0 || k.D.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("webpush: invalid private key")
+	}
+	k.PublicKey.Curve = curve
+	k.PublicKey.X, k.PublicKey.Y = curve.ScalarBaseMult(b)
+	return k, nil
+}
+
+// EncodePublicKey returns a public key as an uncompressed point in unpadded
+// base64url, as browsers take the application server key.
+func EncodePublicKey(k *ecdsa.PublicKey) string {
+	return encoding.EncodeToString(elliptic.Marshal(k.Curve, k.X, k.Y))
+}
+
+// Encrypt encrypts a message to a subscription with a new key and salt in
+// the aes128gcm content encoding.
+func Encrypt(sub *Subscription, message []byte) ([]byte, error) {
+	uaPublic, auth, err := sub.Keys.decode()
+	if err != nil {
+		return nil, err
+	}
+	as, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encrypt(as, uaPublic, auth, salt, message)
+}
+
+func encrypt(as *ecdsa.PrivateKey, uaPublic, auth, salt, message []byte) ([]byte, error) {
+	// The message, its delimiter and the tag must fit in one record.
+	if len(message)+1+16 > recordSize {
+		return nil, errors.New("webpush: message too large")
+	}
+	asPublic := elliptic.Marshal(as.Curve, as.X, as.Y)
+	secret, err := ecdh(as, uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	gcm, nonce, err := contentCipher(secret, auth, salt, uaPublic, asPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerLen, headerLen+len(message)+1+16)
+	copy(header, salt)
+	binary.BigEndian.PutUint32(header[saltLen:], recordSize)
+	header[saltLen+4] = keyLen
+	copy(header[saltLen+5:], asPublic)
+	// 2 delimits the last record.
+	plaintext := append(append([]byte{}, message...), 2)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// Decrypt decrypts a message in the aes128gcm content encoding with a
+// user agent's key and authentication secret, as a browser would.
+func Decrypt(ua *ecdsa.PrivateKey, auth, body []byte) ([]byte, error) {
+	if len(body) < headerLen+16 || body[saltLen+4] != keyLen {
+		return nil, errors.New("webpush: invalid header")
+	}
+	salt := body[:saltLen]
+	if rs := binary.BigEndian.Uint32(body[saltLen:]); int(rs) < len(body)-headerLen {
+		return nil, errors.New("webpush: more than one record")
+	}
+	asPublic := body[saltLen+5 : headerLen]
+	secret, err := ecdh(ua, asPublic)
+	if err != nil {
+		return nil, err
+	}
+	uaPublic := elliptic.Marshal(ua.Curve, ua.X, ua.Y)
+	gcm, nonce, err := contentCipher(secret, auth, salt, uaPublic, asPublic)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, body[headerLen:], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "webpush: decrypt failed")
+	}
+	// Padding follows the delimiter.
+	i := len(plaintext) - 1
+	for i >= 0 && plaintext[i] == 0 {
+		i--
+	}
+	if i < 0 || plaintext[i] != 2 {
+		return nil, errors.New("webpush: invalid padding")
+	}
+	return plaintext[:i], nil
+}
+
+// ecdh returns the x-coordinate of the point shared by a private key and
+// a public key.
+func ecdh(k *ecdsa.PrivateKey, public []byte) ([]byte, error) {
+	x, y := elliptic.Unmarshal(k.Curve, public)
+	if x == nil {
+		return nil, errors.New("webpush: invalid public key")
+	}
+	sx, _ := k.Curve.ScalarMult(x, y, pad(k.D.Bytes()))
+	return pad(sx.Bytes()), nil
+}
+
+// contentCipher derives the content encryption key and nonce of a message.
+func contentCipher(secret, auth, salt, uaPublic, asPublic []byte) (cipher.AEAD, []byte, error) {
+	info := append([]byte("WebPush: info\x00"), uaPublic...)
+	info = append(info, asPublic...)
+	ikm := hkdf(auth, secret, info, 32)
+	prk := extract(salt, ikm)
+	cek := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcm, nonce, nil
+}
+
+// hkdf derives a key of at most 32 bytes (RFC 5869).
+func hkdf(salt, ikm, info []byte, n int) []byte {
+	return expand(extract(salt, ikm), info, n)
+}
+
+func extract(salt, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// expand computes the first block of output, which is all that is needed.
+func expand(prk, info []byte, n int) []byte {
+	mac := hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{1})
+	return mac.Sum(nil)[:n]
+}
+
+// pad left-pads a big-endian integer to 32 bytes.
+func pad(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
+	}
+	v := make([]byte, 32)
+	copy(v[32-len(b):], b)
+	return v
+}
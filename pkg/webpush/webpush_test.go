@@ -0,0 +1,120 @@
+package webpush
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// The example of RFC 8291, section 5.
+const (
+	exampleMessage   = "When I grow up, I want to be a watermelon"
+	exampleASPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	exampleUAPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	exampleUAPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	exampleSalt      = "DGv6ra1nlYgDCS1FRnbzlw"
+	exampleAuth      = "BTBZMqHH6r4Tts7J_aSIgg"
+	exampleBody      = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func TestEncrypt(t *testing.T) {
+	as, err := DecodePrivateKey(exampleASPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ua, err := DecodePrivateKey(exampleUAPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EncodePublicKey(&ua.PublicKey); got != exampleUAPublic {
+		t.Errorf("ua public got %v, want %v", got, exampleUAPublic)
+	}
+	uaPublic, auth, err := Keys{P256dh: exampleUAPublic, Auth: exampleAuth}.decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt, _ := decodeBase64(exampleSalt)
+
+	body, err := encrypt(as, uaPublic, auth, salt, []byte(exampleMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := encoding.EncodeToString(body); got != exampleBody {
+		t.Errorf("got %v, want %v", got, exampleBody)
+	}
+	message, err := Decrypt(ua, auth, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(message) != exampleMessage {
+		t.Errorf("decrypted got %q, want %q", message, exampleMessage)
+	}
+
+	// A new key and salt are used per message.
+	sub := &Subscription{Endpoint: "https://push.example.net/abc", Keys: Keys{P256dh: exampleUAPublic, Auth: exampleAuth + "=="}}
+	a, err := Encrypt(sub, []byte(exampleMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Encrypt(sub, []byte(exampleMessage))
+	if string(a) == string(b) {
+		t.Error("messages encrypted alike")
+	}
+	if message, err := Decrypt(ua, auth, a); err != nil || string(message) != exampleMessage {
+		t.Errorf("decrypted got %q, %v", message, err)
+	}
+	a[len(a)-1] ^= 1
+	if _, err := Decrypt(ua, auth, a); err == nil {
+		t.Error("tampered message decrypted")
+	}
+	if _, err := Encrypt(sub, make([]byte, recordSize)); err == nil {
+		t.Error("oversized message encrypted")
+	}
+	sub.Keys.Auth = "short"
+	if err := sub.Validate(); err == nil {
+		t.Error("invalid auth validated")
+	}
+}
+
+func TestVAPID(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodePrivateKey(EncodePrivateKey(key))
+	if err != nil || decoded.D.Cmp(key.D) != 0 || decoded.X.Cmp(key.X) != 0 {
+		t.Fatalf("decoded key got %v, %v", decoded, err)
+	}
+	v := &VAPID{Key: key, Subject: "mailto:ops@example.com"}
+
+	now := time.Now()
+	endpoint := "https://push.example.net/push/abc"
+	header, err := v.Authorization(endpoint, now.Add(12*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, subject, err := VerifyAuthorization(header, endpoint, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public != EncodePublicKey(&key.PublicKey) || subject != v.Subject {
+		t.Errorf("got %v %v", public, subject)
+	}
+
+	var tests = []struct {
+		header, endpoint string
+		now              time.Time
+		err              string
+	}{
+		{header, "https://other.example.net/push/abc", now, "audience"},
+		{header, endpoint, now.Add(13 * time.Hour), "expired"},
+		{header, endpoint, now.Add(-13 * time.Hour), "too late"},
+		{strings.Replace(header, "t=e", "t=f", 1), endpoint, now, "signature"},
+		{"Bearer x", endpoint, now, "not a vapid"},
+	}
+	for _, tt := range tests {
+		if _, _, err := VerifyAuthorization(tt.header, tt.endpoint, tt.now); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got %v, want %q", tt.err, err, tt.err)
+		}
+	}
+}
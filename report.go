@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// report prints the accuracy of recorded predictions. The database must not
+// be open by a running server.
+func report(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	var (
+		recordPath = fs.String("record-path", "", "database predictions and arrivals were recorded to")
+		routes     = fs.String("routes", "", "comma-separated routes to report on")
+		since      = fs.Duration("since", 7*24*time.Hour, "how far back to report on")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *recordPath == "" {
+		return fmt.Errorf("missing record path")
+	}
+
+	store, err := mta.OpenStore(*recordPath, true)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var filter []string
+	if *routes != "" {
+		filter = strings.Split(*routes, ",")
+	}
+	accuracy, err := store.PredictionAccuracy(filter, time.Now().UTC().Add(-*since))
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "route\tlookahead\tcount\tmean\tp10\tp50\tp90\ton time\t")
+	for _, v := range accuracy {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%v\t%v\t%v\t%v\t%.1f%%\t\n",
+			v.RouteID, v.Bucket, v.Count,
+			v.Mean.Round(time.Second), v.P10, v.P50, v.P90, 100*v.OnTime)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+	"github.com/osamingo/jsonrpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	apiKeyParam  = "api_key"
+	// anonymous is the consumer of requests without an API key.
+	anonymous = "anonymous"
+	// maxRPCBody bounds the body of a JSON-RPC request.
+	maxRPCBody = 1 << 20
+)
+
+var (
+	errAPIKey      = errors.New("invalid API key")
+	errRateLimited = errors.New("rate limit exceeded")
+	errBatchSize   = errors.New("batch exceeds the rate limit")
+
+	// Consumers are those issued an API key, and anonymous, so labeling
+	// by consumer is bounded.
+	usage = metrics.Default.NewCounterVec("mtapi_requests_total",
+		"Requests by consumer and method.", "consumer", "method")
+	rejected = metrics.Default.NewCounterVec("mtapi_rate_limited_total",
+		"Requests refused by the rate limit by consumer.", "consumer")
+)
+
+type (
+	consumerKey struct{}
+	callerKey   struct{}
+)
+
+// consumer returns the consumer a request was authenticated as.
+func consumer(c context.Context) string {
+	if name, ok := c.Value(consumerKey{}).(string); ok {
+		return name
+	}
+	return anonymous
+}
+
+// caller returns who a request is limited as: the consumer of its API key,
+// or, for anonymous requests, the client's address.
+func caller(c context.Context) string {
+	if name, ok := c.Value(callerKey{}).(string); ok {
+		return name
+	}
+	return anonymous
+}
+
+// accessControl authenticates requests by API key and limits their rate:
+// per key for consumers with a key, otherwise per IP address.
+type accessControl struct {
+	// keys maps issued API keys to consumer names.
+	keys      map[string]string
+	keyed     *limiter
+	anonymous *limiter
+	// trustProxy is set when requests come through a proxy that appends
+	// the address of its client to X-Forwarded-For.
+	trustProxy bool
+}
+
+func newAccessControl(keys map[string]string, keyRate, anonymousRate float64, trustProxy bool) *accessControl {
+	return &accessControl{
+		keys:       keys,
+		keyed:      newLimiter(keyRate),
+		anonymous:  newLimiter(anonymousRate),
+		trustProxy: trustProxy,
+	}
+}
+
+// limit wraps a handler with access control. Requests are counted as the
+// given method unless it is empty, as for JSON-RPC, whose methods are
+// counted by methodHandler; each call of a JSON-RPC batch takes a token,
+// and refusals are written as JSON-RPC errors.
+func (a *accessControl) limit(method string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := 1
+		fail := func(err error) { writeError(w, err) }
+		if method == "" {
+			var (
+				id  *fastjson.RawMessage
+				err error
+			)
+			n, id, err = rpcCalls(w, r)
+			fail = func(err error) { writeRPCError(w, id, err) }
+			if err != nil {
+				fail(&jsonrpc.Error{Code: jsonrpc.ErrorCodeInvalidRequest, Message: err.Error()})
+				return
+			}
+		}
+
+		name, key, l := anonymous, a.clientIP(r), a.anonymous
+		who := anonymous + " " + key
+		if k := apiKey(r); k != "" {
+			var ok bool
+			if name, ok = a.keys[k]; !ok {
+				fail(errAPIKey)
+				return
+			}
+			key, l, who = k, a.keyed, name
+		}
+		if l.exceedsBurst(n) {
+			rejected.Inc(name)
+			fail(errBatchSize)
+			return
+		}
+		if ok, wait := l.allow(key, n, time.Now()); !ok {
+			rejected.Inc(name)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			fail(errRateLimited)
+			return
+		}
+		if method != "" {
+			usage.Inc(name, method)
+		}
+		c := context.WithValue(r.Context(), consumerKey{}, name)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(c, callerKey{}, who)))
+	})
+}
+
+func apiKey(r *http.Request) string {
+	if k := r.Header.Get(apiKeyHeader); k != "" {
+		return k
+	}
+	return r.URL.Query().Get(apiKeyParam)
+}
+
+// rpcCalls returns the number of calls in a JSON-RPC request, which is more
+// than one for a batch, and the ID of a single call, leaving the body to be
+// read again. The ID is null for a batch or when it cannot be read.
+func rpcCalls(w http.ResponseWriter, r *http.Request) (int, *fastjson.RawMessage, error) {
+	null := fastjson.RawMessage("null")
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRPCBody))
+	r.Body.Close()
+	if err != nil {
+		return 0, &null, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '[' {
+		var call struct{ ID json.RawMessage }
+		if err := json.Unmarshal(body, &call); err != nil || len(call.ID) == 0 {
+			return 1, &null, nil
+		}
+		id := fastjson.RawMessage(call.ID)
+		return 1, &id, nil
+	}
+	// An invalid batch is rejected by the dispatcher.
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+		return 1, &null, nil
+	}
+	return len(batch), &null, nil
+}
+
+// clientIP returns the address of the client. Behind a trusted proxy, e.g.,
+// the Heroku router, it is the last address of X-Forwarded-For, which the
+// proxy appends; earlier addresses are set by the client and not trusted.
+func (a *accessControl) clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); a.trustProxy && forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		return strings.TrimSpace(hops[len(hops)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+	"github.com/osamingo/jsonrpc"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(1)
+	now := time.Now()
+
+	burst := int(burstDuration / time.Second)
+	for i := 0; i < burst; i++ {
+		if ok, _ := l.allow("a", 1, now); !ok {
+			t.Fatalf("request %d denied within burst", i)
+		}
+	}
+	ok, wait := l.allow("a", 1, now)
+	if ok || wait != time.Second {
+		t.Errorf("got %v %v, want %v %v", ok, wait, false, time.Second)
+	}
+	if ok, _ := l.allow("b", 1, now); !ok {
+		t.Errorf("b denied by the bucket of a")
+	}
+	if ok, _ := l.allow("a", 1, now.Add(time.Second)); !ok {
+		t.Errorf("a denied after refill")
+	}
+
+	if ok, wait := l.allow("c", burst, now); !ok || wait != 0 {
+		t.Errorf("c denied a burst at once")
+	}
+	if ok, wait := l.allow("c", 2, now.Add(time.Second)); ok || wait != time.Second {
+		t.Errorf("got %v %v, want %v %v", ok, wait, false, time.Second)
+	}
+	if !l.exceedsBurst(burst + 1) {
+		t.Errorf("exceedsBurst(%d) got false", burst+1)
+	}
+
+	l.prune(now.Add(burstDuration + time.Second))
+	if got := len(l.buckets); got != 0 {
+		t.Errorf("len(buckets) got %v, want %v", got, 0)
+	}
+
+	if ok, _ := newLimiter(0).allow("a", 1, now); !ok {
+		t.Errorf("unlimited limiter denied")
+	}
+}
+
+func TestAccessControl(t *testing.T) {
+	a := newAccessControl(map[string]string{"secret": "partner"}, 2, 0.2, true)
+	var got, gotCaller string
+	h := a.limit("GET /test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, gotCaller = consumer(r.Context()), caller(r.Context())
+	}))
+
+	// The router appends the address of the client to X-Forwarded-For,
+	// ip, after any the client sent.
+	var tests = []struct {
+		path, key, forwarded, ip string
+		status                   int
+		consumer, caller         string
+	}{
+		{"/test", "", "", "1.1.1.1", 200, anonymous, "anonymous 1.1.1.1"},
+		{"/test", "", "9.9.9.9", "1.1.1.1", 429, "", ""},
+		{"/test", "", "", "2.2.2.2", 200, anonymous, "anonymous 2.2.2.2"},
+		{"/test", "secret", "", "1.1.1.1", 200, "partner", "partner"},
+		{"/test?api_key=secret", "", "", "1.1.1.1", 200, "partner", "partner"},
+		{"/test", "wrong", "", "3.3.3.3", 401, "", ""},
+	}
+	for _, tt := range tests {
+		got, gotCaller = "", ""
+		r := httptest.NewRequest("GET", tt.path, nil)
+		forwarded := tt.ip
+		if tt.forwarded != "" {
+			forwarded = tt.forwarded + ", " + tt.ip
+		}
+		r.Header.Set("X-Forwarded-For", forwarded)
+		if tt.key != "" {
+			r.Header.Set(apiKeyHeader, tt.key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%s %s: status got %v, want %v", tt.path, tt.ip, w.Code, tt.status)
+		}
+		if got != tt.consumer {
+			t.Errorf("%s %s: consumer got %v, want %v", tt.path, tt.ip, got, tt.consumer)
+		}
+		if gotCaller != tt.caller {
+			t.Errorf("%s %s: caller got %v, want %v", tt.path, tt.ip, gotCaller, tt.caller)
+		}
+		if tt.status == 429 && w.Header().Get("Retry-After") == "" {
+			t.Errorf("%s %s: missing Retry-After", tt.path, tt.ip)
+		}
+	}
+
+	var b bytes.Buffer
+	metrics.Default.WriteText(&b)
+	for _, want := range []string{
+		`mtapi_requests_total{consumer="partner",method="GET /test"}`,
+		`mtapi_rate_limited_total{consumer="anonymous"}`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
+
+func TestBearer(t *testing.T) {
+	h := bearer("t0ken", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		authorization string
+		status        int
+	}{
+		{"", 401},
+		{"Bearer wrong", 401},
+		{"t0ken", 401},
+		{"Bearer t0ken", 200},
+	} {
+		r := httptest.NewRequest("GET", "/metrics", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%q: status got %v, want %v", tt.authorization, w.Code, tt.status)
+		}
+	}
+}
+
+func TestAccessControlBatch(t *testing.T) {
+	a := newAccessControl(nil, 0, 1, false)
+	var calls int
+	h := a.limit("", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		calls += strings.Count(string(body), "jsonrpc")
+	}))
+	call := `{"jsonrpc":"2.0","id":1,"method":"GetRoutes"}`
+	var tests = []struct {
+		body   string
+		status int
+		// id is the ID of the JSON-RPC error refusing the request.
+		id string
+	}{
+		{"[" + strings.Repeat(call+",", 3) + call + "]", 200, ""},
+		// One token is left of the burst of five.
+		{"[" + call + "," + call + "]", 429, "null"},
+		{call, 200, ""},
+		{call, 429, "1"},
+		{"[" + strings.Repeat(call+",", 5) + call + "]", 413, "null"},
+	}
+	for i, tt := range tests {
+		r := httptest.NewRequest("POST", "/rpc", strings.NewReader(tt.body))
+		r.RemoteAddr = "1.1.1.1:1234"
+		// Not behind a trusted proxy, X-Forwarded-For is ignored.
+		r.Header.Set("X-Forwarded-For", strconv.Itoa(i))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%d: status got %v, want %v", i, w.Code, tt.status)
+		}
+		if tt.id == "" {
+			continue
+		}
+		var resp struct {
+			Version string `json:"jsonrpc"`
+			ID      json.RawMessage
+			Error   *jsonrpc.Error
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Version != "2.0" || string(resp.ID) != tt.id || resp.Error == nil {
+			t.Errorf("%d: response got %s, want an error with id %s", i, w.Body, tt.id)
+		}
+	}
+	if calls != 5 {
+		t.Errorf("calls got %v, want %v", calls, 5)
+	}
+}
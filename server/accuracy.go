@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+const defaultAccuracyWindow = 24 * time.Hour
+
+// GetPredictionAccuracyHandler returns the error distribution of recorded
+// predictions.
+type GetPredictionAccuracyHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetPredictionAccuracyParams defines the parameters of the
+// GetPredictionAccuracy RPC.
+type GetPredictionAccuracyParams struct {
+	Routes []string
+	// Since defaults to a day ago.
+	Since *time.Time
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetPredictionAccuracyHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetPredictionAccuracyParams
+	if params != nil {
+		if err := jsonrpc.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	since := time.Now().UTC().Add(-defaultAccuracyWindow)
+	if p.Since != nil {
+		since = *p.Since
+	}
+
+	accuracy, err := h.client.GetPredictionAccuracy(p.Routes, since)
+	if err != nil {
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInternal,
+			Message: err.Error(),
+		}
+	}
+	return GetPredictionAccuracyResult{Accuracy: h.p.Accuracy(accuracy)}, nil
+}
+
+// GetPredictionAccuracyResult describes the response of the
+// GetPredictionAccuracy RPC.
+type GetPredictionAccuracyResult struct{ Accuracy []*protocol.Accuracy }
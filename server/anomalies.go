@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+// GetAnomaliesHandler returns recently detected ghost, stalled and bunched
+// trains.
+type GetAnomaliesHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetAnomaliesParams defines the parameters of the GetAnomalies RPC.
+type GetAnomaliesParams struct{ Routes []string }
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetAnomaliesHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetAnomaliesParams
+	if params != nil {
+		if err := jsonrpc.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	return GetAnomaliesResult{Anomalies: h.p.Anomalies(h.client.GetAnomalies(p.Routes))}, nil
+}
+
+// GetAnomaliesResult describes the response of the GetAnomalies RPC.
+type GetAnomaliesResult struct{ Anomalies []*protocol.Anomaly }
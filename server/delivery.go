@@ -0,0 +1,330 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// deliveryAttempts is how many times an event is posted to a webhook
+	// before it is dropped.
+	deliveryAttempts = 5
+	// deliveryTimeout bounds a post to a webhook.
+	deliveryTimeout = 10 * time.Second
+	// deliveryRetry is the wait before retrying a destination after a
+	// failure, doubled per consecutive failure up to maxDeliveryRetry.
+	deliveryRetry    = 5 * time.Second
+	maxDeliveryRetry = deliveryRetry << (deliveryAttempts - 2)
+	// deliveryQueue is the number of events waiting to be delivered
+	// beyond which new events are dropped.
+	deliveryQueue   = 256
+	deliveryWorkers = 4
+)
+
+var errGone = errors.New("gone")
+
+// privateNets are the networks messages are not delivered to, so that a URL
+// cannot reach the server's own network: unspecified, loopback, private,
+// shared (RFC 6598), link-local and unique local addresses.
+var privateNets = parseCIDRs(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8",
+	"169.254.0.0/16", "172.16.0.0/12", "192.168.0.0/16",
+	"::/128", "::1/128", "fc00::/7", "fe80::/10",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// publicIP reports whether messages may be delivered to an address.
+func publicIP(ip net.IP) bool {
+	if ip.IsMulticast() {
+		return false
+	}
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliveryClient returns a client that dials only the addresses allowed,
+// checking every address a host resolves to, and follows no redirects.
+func deliveryClient(allowed func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{Timeout: deliveryTimeout}
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range addrs {
+			if !allowed(a.IP) {
+				return nil, errors.Errorf("%s resolves to %s, which is not public", host, a.IP)
+			}
+		}
+		// The address checked is dialed rather than the host, which may
+		// resolve differently the second time.
+		return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+	}
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			DialContext:         dial,
+			TLSHandshakeTimeout: deliveryTimeout,
+			MaxIdleConnsPerHost: deliveryWorkers,
+			IdleConnTimeout:     time.Minute,
+		},
+		// A redirect could lead anywhere, so it is answered as is.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+var (
+	webhookDeliveries = metrics.Default.NewCounterVec("mtapi_webhook_deliveries_total",
+		"Webhook events by result: ok, retried, failed, gone or dropped.", "result")
+	pushDeliveries = metrics.Default.NewCounterVec("mtapi_push_deliveries_total",
+		"Web Push messages by result: ok, retried, failed, gone or dropped.", "result")
+)
+
+// delivery is a message to post to a URL.
+type delivery struct {
+	id, url string
+	header  http.Header
+	body    []byte
+	// gone, if set, is called when the URL answers that it no longer
+	// exists.
+	gone func()
+	// attempts is the number of times the message was posted.
+	attempts int
+}
+
+// deliverer posts messages, retrying failures with backoff. The workers
+// only post: a message that failed waits with the others to its
+// destination, which are requeued together once the destination's backoff
+// has passed, so a failing destination does not hold up the others.
+type deliverer struct {
+	// name prefixes the deliverer's logs.
+	name    string
+	counter *metrics.CounterVec
+	client  *http.Client
+	queue   chan *delivery
+	// retry is the wait after the first failure of a destination.
+	retry time.Duration
+	// allowed reports whether messages may be delivered to an address.
+	allowed func(net.IP) bool
+
+	mtx      sync.Mutex
+	backoffs map[string]*destinationBackoff
+}
+
+// destinationBackoff is a destination whose last post failed. While its
+// timer is pending, messages to it wait rather than being posted.
+type destinationBackoff struct {
+	// wait is the wait after the next failure.
+	wait    time.Duration
+	timer   *time.Timer
+	waiting []*delivery
+}
+
+func newDeliverer(name string, counter *metrics.CounterVec) *deliverer {
+	d := &deliverer{
+		name:     name,
+		counter:  counter,
+		queue:    make(chan *delivery, deliveryQueue),
+		retry:    deliveryRetry,
+		allowed:  publicIP,
+		backoffs: make(map[string]*destinationBackoff),
+	}
+	d.client = deliveryClient(func(ip net.IP) bool { return d.allowed(ip) })
+	return d
+}
+
+// checkURL returns an error if a URL is not one messages may be delivered
+// to: an absolute URL of one of the schemes whose host, if an address, is
+// allowed. Host names are checked once resolved, when delivering.
+func (d *deliverer) checkURL(rawURL string, schemes ...string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || !strings2.SliceContains(schemes, u.Scheme) {
+		return errors.Errorf("invalid URL %q", rawURL)
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil && !d.allowed(ip) || host == "localhost" {
+		return errors.Errorf("URL %q is not public", rawURL)
+	}
+	return nil
+}
+
+// enqueue queues a message, dropping it if the queue is full.
+func (d *deliverer) enqueue(v *delivery) {
+	select {
+	case d.queue <- v:
+	default:
+		d.counter.Inc("dropped")
+		log.Printf("%s: queue full, dropped %s", d.name, v.id)
+	}
+}
+
+// run delivers queued messages until quit is closed.
+func (d *deliverer) run(quit <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-quit
+		cancel()
+	}()
+	for i := 0; i < deliveryWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v := <-d.queue:
+					d.deliver(ctx, v)
+				}
+			}
+		}()
+	}
+}
+
+// deliver posts a message once, unless its destination is backing off,
+// and schedules it to be retried if it failed and attempts remain.
+func (d *deliverer) deliver(ctx context.Context, v *delivery) {
+	if d.wait(v) {
+		return
+	}
+	v.attempts++
+	retry, err := d.post(ctx, v)
+	switch {
+	case err == nil:
+		d.forget(v.url)
+		d.counter.Inc("ok")
+	case err == errGone && v.gone != nil:
+		d.forget(v.url)
+		d.counter.Inc("gone")
+		v.gone()
+	case !retry || v.attempts == deliveryAttempts:
+		d.forget(v.url)
+		d.counter.Inc("failed")
+		log.Printf("%s: delivery %s to %s failed: %v", d.name, v.id, v.url, err)
+	case ctx.Err() == nil:
+		d.counter.Inc("retried")
+		d.backOff(ctx, v)
+	}
+}
+
+// wait holds a message if its destination is backing off, reporting
+// whether it did.
+func (d *deliverer) wait(v *delivery) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	b, ok := d.backoffs[v.url]
+	if !ok || b.timer == nil {
+		return false
+	}
+	b.waiting = append(b.waiting, v)
+	return true
+}
+
+// backOff holds a message that failed until its destination's backoff has
+// passed, starting the backoff unless it is pending.
+func (d *deliverer) backOff(ctx context.Context, v *delivery) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	b, ok := d.backoffs[v.url]
+	if !ok {
+		b = &destinationBackoff{wait: d.retry}
+		d.backoffs[v.url] = b
+	}
+	b.waiting = append(b.waiting, v)
+	if b.timer != nil {
+		return
+	}
+	b.timer = time.AfterFunc(b.wait, func() { d.release(ctx, v.url) })
+	if b.wait *= 2; b.wait > maxDeliveryRetry {
+		b.wait = maxDeliveryRetry
+	}
+}
+
+// release requeues the messages waiting for a destination whose backoff
+// has passed.
+func (d *deliverer) release(ctx context.Context, url string) {
+	d.mtx.Lock()
+	b := d.backoffs[url]
+	waiting := b.waiting
+	b.waiting, b.timer = nil, nil
+	d.mtx.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	for _, v := range waiting {
+		d.enqueue(v)
+	}
+}
+
+// forget drops the backoff of a destination once no message waits for it,
+// after a message to it was accepted or given up on.
+func (d *deliverer) forget(url string) {
+	d.mtx.Lock()
+	if b, ok := d.backoffs[url]; ok && b.timer == nil && len(b.waiting) == 0 {
+		delete(d.backoffs, url)
+	}
+	d.mtx.Unlock()
+}
+
+// post posts a message once, reporting whether a failure may be retried.
+// Requests that fail to complete, time out, or are answered with 429 or a
+// server error are retried; other errors are not. Answers of 404 and 410
+// are errGone.
+func (d *deliverer) post(ctx context.Context, v *delivery) (bool, error) {
+	req, err := http.NewRequest("POST", v.url, bytes.NewReader(v.body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	for k, values := range v.header {
+		req.Header[k] = values
+	}
+	req.Header.Set("User-Agent", "mtapi")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, errors.Errorf("status %s", resp.Status)
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return false, errGone
+	}
+	return false, errors.Errorf("status %s", resp.Status)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+)
+
+// anyIP allows delivering to the test servers on the loopback interface.
+func anyIP(net.IP) bool { return true }
+
+func TestPublicIP(t *testing.T) {
+	var tests = []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.31.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"224.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) got %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryClient(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer redirect.Close()
+
+	if _, err := deliveryClient(publicIP).Get(target.URL); err == nil {
+		t.Error("loopback: got no error")
+	}
+	resp, err := deliveryClient(anyIP).Get(redirect.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("redirect: status got %v, want %v", resp.StatusCode, http.StatusFound)
+	}
+}
+
+func TestDelivererBackoff(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		posts    = make(map[string]int)
+		failures = 2
+	)
+	delivered := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		posts[r.URL.Path]++
+		fail := r.URL.Path == "/down" && failures > 0
+		if fail {
+			failures--
+		}
+		mtx.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		delivered <- r.URL.Path
+	}))
+	defer receiver.Close()
+
+	d := newDeliverer("test", metrics.NewRegistry().NewCounterVec("deliveries_total", "", "result"))
+	d.allowed = anyIP
+	d.retry = 100 * time.Millisecond
+	quit := make(chan struct{})
+	defer close(quit)
+	d.run(quit)
+
+	d.enqueue(&delivery{id: "1", url: receiver.URL + "/down"})
+	// The destination that is up is not held up by the one backing off.
+	select {
+	case <-time.After(50 * time.Millisecond):
+	case path := <-delivered:
+		t.Fatalf("got %s before the backoff", path)
+	}
+	d.enqueue(&delivery{id: "2", url: receiver.URL + "/down"})
+	d.enqueue(&delivery{id: "3", url: receiver.URL + "/up"})
+	if got := <-delivered; got != "/up" {
+		t.Fatalf("got %s, want /up first", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-delivered:
+			if got != "/down" {
+				t.Errorf("got %s, want /down", got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d deliveries to /down, want 2", i)
+		}
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	// The message sent while the destination was backing off waited rather
+	// than failing again: two failures and two deliveries.
+	if got := posts["/down"]; got != 4 {
+		t.Errorf("posts to /down got %v, want 4", got)
+	}
+}
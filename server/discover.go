@@ -0,0 +1,320 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+	"github.com/osamingo/jsonrpc"
+)
+
+const openRPCVersion = "1.2.6"
+
+// methodDoc documents a JSON-RPC method beyond what reflection tells.
+type methodDoc struct {
+	Summary     string
+	Description string
+	Required    []string
+	Example     map[string]interface{}
+}
+
+var methodDocs = map[string]methodDoc{
+	"GetSystemStatus": {
+		Summary:     "Returns the service status of each subway line.",
+		Description: "The status is fetched from the MTA every minute. Each line lists the routes it groups and a category: good_service, delays, planned_work, service_change or suspended.",
+	},
+	"GetStations": {
+		Summary:     "Returns all stations.",
+		Description: "Stations are aggregated from GTFS stops and transfers; arrivals are not included.",
+	},
+	"GetStation": {
+		Summary:  "Returns a station and its upcoming arrivals.",
+		Required: []string{"ID"},
+		Example:  map[string]interface{}{"ID": "L03", "Routes": []string{"Q"}, "Directions": []string{"S"}, "Limit": 3},
+	},
+	"GetClosestStations": {
+		Summary:     "Returns the stations closest to a location and their upcoming arrivals.",
+		Description: "At most five stations are returned.",
+		Required:    []string{"Lat", "Lon"},
+		Example:     map[string]interface{}{"Lat": 40.7347908, "Lon": -73.9907299, "NumStations": 3, "Within": 900},
+	},
+	"GetRoutes": {
+		Summary: "Returns all routes.",
+	},
+	"GetHeadways": {
+		Summary:     "Returns the headways of a route at a station.",
+		Description: "Predicted gaps between trains are compared to the scheduled headway; gaps exceeding it by more than the threshold are flagged. No gaps are flagged when the schedule is unknown. Express variants count as their route, e.g., the 6X as the 6.",
+		Required:    []string{"RouteID", "StationID"},
+		Example:     map[string]interface{}{"RouteID": "Q", "StationID": "L03", "Threshold": 300},
+	},
+	"GetAnomalies": {
+		Summary:     "Returns recently detected ghost, stalled and bunched trains.",
+		Description: "Anomalies are kept for an hour.",
+		Example:     map[string]interface{}{"Routes": []string{"L"}},
+	},
+	"GetPredictionAccuracy": {
+		Summary:     "Returns the distribution of prediction error by route and lookahead.",
+		Description: "Only available when the server records predictions. The feeds do not report arrivals, so a train is taken to arrive at its last prediction for the stop, capped at when it was found past it, which biases the error toward zero. Since is rounded down to the minute, and results may be a minute old.",
+		Example:     map[string]interface{}{"Routes": []string{"Q"}, "Since": "2018-06-01T00:00:00Z"},
+	},
+	"GetPlannedWork": {
+		Summary:     "Returns the planned work and service changes affecting routes between two times.",
+		Description: "Work is announced by the alerts of the feeds and by the service status, whose descriptions are parsed for dates and times. Only the periods of the work between From and To, by default the coming week, are returned.",
+		Example:     map[string]interface{}{"Routes": []string{"L", "7"}, "From": "2018-06-15T16:00:00Z", "To": "2018-06-18T09:00:00Z"},
+	},
+	"GetStatusHistory": {
+		Summary:     "Returns the changes of service status of the lines serving a route.",
+		Description: "Changes are kept for a month, and survive restarts when the server records. A line is taken to have changed when the MTA posted its status, if known.",
+		Example:     map[string]interface{}{"RouteID": "L", "Since": "2018-06-01T00:00:00Z"},
+	},
+	"GetStatusStats": {
+		Summary:     "Returns the minutes each line spent in each status category per day in New York.",
+		Description: "Only days for which the status of a line is known are returned.",
+		Example:     map[string]interface{}{"Routes": []string{"L"}, "Days": 7},
+	},
+	"CreateWebhook": {
+		Summary:     "Registers a URL to notify of changes of service status or of delays of the next train at a station.",
+		Description: "Requires an API key; webhooks belong to the key that registered them and are kept in memory. Events are posted as JSON with the hex HMAC-SHA256 of X-Mtapi-Timestamp, a period and the body keyed by the returned secret in X-Mtapi-Signature, and retried with backoff while the URL fails to answer or answers 429 or 5xx. Redirects are not followed, and URLs of private, loopback or link-local addresses are refused. RouteID is kept as the public route ID, e.g., 6 for 6X.",
+		Example:     map[string]interface{}{"URL": "https://example.com/hooks/mtapi", "Kind": "delay", "RouteID": "7", "StationID": "710", "Direction": "S", "MinDelay": 300},
+	},
+	"ListWebhooks": {
+		Summary: "Returns the webhooks of the API key.",
+	},
+	"DeleteWebhook": {
+		Summary: "Unregisters a webhook of the API key.",
+		Example: map[string]interface{}{"WebhookID": "9f86d081884c7d65"},
+	},
+	"SubscribePush": {
+		Summary:     "Subscribes a browser to Web Push notifications of the next trains at stations and changes of service status of routes.",
+		Description: "Subscribe with PushManager.subscribe using the server's VAPID public key, published to the bundled client, and pass the subscription as JSON. Subscribing again replaces the stations and routes; subscribing to neither unsubscribes. Routes are kept as public route IDs, e.g., 6 for 6X, and unknown routes are refused. The endpoint must be HTTPS and public, and at most 50 browsers may be subscribed per address or API key. Fails if the server has no VAPID key.",
+		Example: map[string]interface{}{
+			"Subscription": map[string]interface{}{
+				"endpoint": "https://push.example.net/push/abc",
+				"keys":     map[string]string{"p256dh": "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "auth": "BTBZMqHH6r4Tts7J_aSIgg"},
+			},
+			"Stations": []string{"L03"},
+			"Routes":   []string{"L"},
+		},
+	},
+	"rpc.discover": {
+		Summary: "Returns the OpenRPC document describing this API.",
+	},
+}
+
+// paramDocs describes parameters, which are named consistently across
+// methods.
+var paramDocs = map[string]string{
+	"ID":           "Station ID, e.g., L03.",
+	"StationID":    "Station ID, e.g., L03.",
+	"RouteID":      "Route ID, e.g., Q.",
+	"Routes":       "Route IDs to restrict results to.",
+	"Directions":   `Directions to restrict arrivals to, "N" or "S".`,
+	"Limit":        "Maximum number of arrivals per direction.",
+	"Within":       "Time horizon for arrivals in seconds.",
+	"Lat":          "Latitude.",
+	"Lon":          "Longitude.",
+	"NumStations":  "Number of stations, at most five.",
+	"Threshold":    "Seconds a gap may exceed the scheduled headway before it is flagged; defaults to 300.",
+	"Since":        "Start of the reporting period; defaults to a day ago.",
+	"From":         "Start of the span of time; defaults to now.",
+	"To":           "End of the span of time; defaults to a week after From.",
+	"Days":         "Number of days ending today in New York, at most 31; defaults to 7.",
+	"URL":          "URL events are posted to, over HTTP or HTTPS, whose host must be public.",
+	"Kind":         `Events to notify: "status" for changes of service status or "delay" for delays of the next train.`,
+	"Direction":    `Direction of the trains, "N" or "S".`,
+	"MinDelay":     "Seconds the next train may be delayed before it is notified; defaults to 300.",
+	"WebhookID":    "Webhook ID, as returned by CreateWebhook.",
+	"Subscription": "The browser's PushSubscription as JSON.",
+	"Stations":     "Station IDs, e.g., L03.",
+}
+
+// schemas collects JSON schemas for a document, rewriting references to
+// point into its components.
+type schemas struct {
+	definitions map[string]*jsonschema.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{definitions: make(map[string]*jsonschema.Type)}
+}
+
+// reflect returns the schema of v; with expand, the properties of a struct
+// are returned rather than a reference to it.
+func (s *schemas) reflect(v interface{}, expand bool) *jsonschema.Type {
+	r := &jsonschema.Reflector{ExpandedStruct: expand}
+	schema := r.Reflect(v)
+	for name, t := range schema.Definitions {
+		s.definitions[name] = rewriteRefs(t)
+	}
+	return rewriteRefs(schema.Type)
+}
+
+func rewriteRefs(t *jsonschema.Type) *jsonschema.Type {
+	if t == nil {
+		return nil
+	}
+	t.Version = ""
+	t.Ref = strings.Replace(t.Ref, "#/definitions/", "#/components/schemas/", 1)
+	rewriteRefs(t.Items)
+	rewriteRefs(t.AdditionalItems)
+	for _, v := range t.Properties {
+		rewriteRefs(v)
+	}
+	for _, v := range t.PatternProperties {
+		rewriteRefs(v)
+	}
+	for _, v := range t.OneOf {
+		rewriteRefs(v)
+	}
+	return t
+}
+
+type openRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       openRPCInfo       `json:"info"`
+	Servers    []openRPCServer   `json:"servers"`
+	Methods    []*openRPCMethod  `json:"methods"`
+	Components openRPCComponents `json:"components"`
+}
+
+type openRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openRPCServer struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type openRPCMethod struct {
+	Name           string                   `json:"name"`
+	Summary        string                   `json:"summary,omitempty"`
+	Description    string                   `json:"description,omitempty"`
+	ParamStructure string                   `json:"paramStructure"`
+	Params         []*openRPCContent        `json:"params"`
+	Result         *openRPCContent          `json:"result"`
+	Examples       []*openRPCExamplePairing `json:"examples,omitempty"`
+}
+
+type openRPCContent struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Required    bool             `json:"required,omitempty"`
+	Schema      *jsonschema.Type `json:"schema"`
+}
+
+type openRPCExamplePairing struct {
+	Name   string            `json:"name"`
+	Params []*openRPCExample `json:"params"`
+}
+
+type openRPCExample struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+type openRPCComponents struct {
+	Schemas map[string]*jsonschema.Type `json:"schemas"`
+}
+
+// openRPC generates the OpenRPC document of the registered methods.
+func openRPC(mr *jsonrpc.MethodRepository, release, url string) *openRPCDocument {
+	s := newSchemas()
+	methods := mr.Methods()
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	doc := &openRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: openRPCInfo{
+			Title:       "mtapi",
+			Description: "JSON-RPC 2.0 wrapper for the MTA API",
+			Version:     release,
+		},
+		Servers: []openRPCServer{{Name: "default", URL: url}},
+	}
+	for _, name := range names {
+		md := methods[name]
+		docs := methodDocs[name]
+		m := &openRPCMethod{
+			Name:           name,
+			Summary:        docs.Summary,
+			Description:    docs.Description,
+			ParamStructure: "by-name",
+			Params:         []*openRPCContent{},
+		}
+		if md.Params != nil {
+			params := s.reflect(md.Params, true)
+			keys := make([]string, 0, len(params.Properties))
+			for k := range params.Properties {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				m.Params = append(m.Params, &openRPCContent{
+					Name:        k,
+					Description: paramDocs[k],
+					Required:    strings2.SliceContains(docs.Required, k),
+					Schema:      params.Properties[k],
+				})
+			}
+		}
+		result := &openRPCContent{Name: "result", Schema: &jsonschema.Type{}}
+		if md.Result != nil {
+			result.Name = reflect.TypeOf(md.Result).Name()
+			result.Schema = s.reflect(md.Result, false)
+		}
+		m.Result = result
+		if docs.Example != nil {
+			example := &openRPCExamplePairing{Name: name + " example"}
+			for _, p := range m.Params {
+				if v, ok := docs.Example[p.Name]; ok {
+					example.Params = append(example.Params, &openRPCExample{Name: p.Name, Value: v})
+				}
+			}
+			m.Examples = []*openRPCExamplePairing{example}
+		}
+		doc.Methods = append(doc.Methods, m)
+	}
+	doc.Components.Schemas = s.definitions
+	return doc
+}
+
+// DiscoverHandler returns the OpenRPC document, generated once all methods
+// are registered.
+type DiscoverHandler struct {
+	mr      *jsonrpc.MethodRepository
+	release string
+	url     string
+
+	once sync.Once
+	doc  *openRPCDocument
+}
+
+func (h *DiscoverHandler) document() *openRPCDocument {
+	h.once.Do(func() { h.doc = openRPC(h.mr, h.release, h.url) })
+	return h.doc
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h *DiscoverHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	return h.document(), nil
+}
+
+// ServeHTTP serves the OpenRPC document.
+func (h *DiscoverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.document())
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/julienschmidt/httprouter"
+)
+
+var refPattern = regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`)
+
+// unresolved returns the schema references of doc missing from its
+// components.
+func unresolved(t *testing.T, doc interface{}, components map[string]bool) []string {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var missing []string
+	for _, m := range refPattern.FindAllStringSubmatch(string(b), -1) {
+		if !components[m[1]] {
+			missing = append(missing, m[1])
+		}
+	}
+	return missing
+}
+
+func TestOpenRPC(t *testing.T) {
+	s := New(&Params{Client: client(t), Release: "test"})
+	doc := openRPC(s.dispatcher, "test", "/rpc")
+
+	methods := s.dispatcher.Methods()
+	if got, want := len(doc.Methods), len(methods); got != want {
+		t.Errorf("len(methods) got %v, want %v", got, want)
+	}
+	for _, m := range doc.Methods {
+		if m.Summary == "" {
+			t.Errorf("%s: missing summary", m.Name)
+		}
+		if m.Result == nil || m.Result.Schema == nil {
+			t.Errorf("%s: missing result schema", m.Name)
+		}
+		for _, p := range m.Params {
+			if p.Description == "" {
+				t.Errorf("%s: missing description of %s", m.Name, p.Name)
+			}
+		}
+	}
+
+	components := make(map[string]bool)
+	for name := range doc.Components.Schemas {
+		components[name] = true
+	}
+	if missing := unresolved(t, doc, components); len(missing) > 0 {
+		t.Errorf("unresolved references %v", missing)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc := openAPI("test")
+
+	c := client(t)
+	rest := &restAPI{
+		prefix:   "/api/v1",
+		client:   c,
+		stations: GetStationsHandler{client: c, p: protocol.New()},
+	}
+	m := httprouter.New()
+	rest.register(m, nil)
+	for path := range doc.Paths {
+		route := strings.Replace(path, "{id}", "L03", 1)
+		if h, _, _ := m.Lookup("GET", route); h == nil {
+			t.Errorf("%s: not routed", path)
+		}
+	}
+
+	components := make(map[string]bool)
+	for name := range doc.Components.Schemas {
+		components[name] = true
+	}
+	if missing := unresolved(t, doc, components); len(missing) > 0 {
+		t.Errorf("unresolved references %v", missing)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/osamingo/jsonrpc"
+	"github.com/pkg/errors"
+)
+
+// Codes of the JSON-RPC errors of access control, in the range reserved
+// for implementations.
+const (
+	errorCodeAPIKey      jsonrpc.ErrorCode = -32001
+	errorCodeRateLimited jsonrpc.ErrorCode = -32002
+	errorCodeBatchSize   jsonrpc.ErrorCode = -32003
+)
+
+// rpcError converts an error from a handler into a JSON-RPC error.
+func rpcError(err error) *jsonrpc.Error {
+	cause := errors.Cause(err)
+	if e, ok := cause.(*jsonrpc.Error); ok {
+		return e
+	}
+	code := jsonrpc.ErrorCodeInternal
+	switch cause {
+	case mta.ErrStationNotFound:
+		code = jsonrpc.ErrorCodeInvalidParams
+	case errAPIKey:
+		code = errorCodeAPIKey
+	case errRateLimited:
+		code = errorCodeRateLimited
+	case errBatchSize:
+		code = errorCodeBatchSize
+	}
+	return &jsonrpc.Error{Code: code, Message: err.Error()}
+}
+
+// httpStatus returns the status code matching an error from a handler.
+func httpStatus(err error) int {
+	cause := errors.Cause(err)
+	switch cause {
+	case mta.ErrStationNotFound:
+		return http.StatusNotFound
+	case mta.ErrServiceStatusUnavailable:
+		return http.StatusServiceUnavailable
+	case errAPIKey:
+		return http.StatusUnauthorized
+	case errRateLimited:
+		return http.StatusTooManyRequests
+	case errBatchSize:
+		return http.StatusRequestEntityTooLarge
+	}
+	if e, ok := cause.(*jsonrpc.Error); ok {
+		switch e.Code {
+		case jsonrpc.ErrorCodeParse, jsonrpc.ErrorCodeInvalidRequest, jsonrpc.ErrorCodeInvalidParams:
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// writeRPCError writes an error refusing a JSON-RPC request as a response
+// to the call with the given ID.
+func writeRPCError(w http.ResponseWriter, id *fastjson.RawMessage, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(httpStatus(err))
+	json.NewEncoder(w).Encode(&jsonrpc.Response{ID: id, Version: jsonrpc.Version, Error: rpcError(err)})
+}
+
+// writeError writes an error from a handler as a JSON body.
+func writeError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	if e, ok := errors.Cause(err).(*jsonrpc.Error); ok {
+		msg = e.Message
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(httpStatus(err))
+	json.NewEncoder(w).Encode(struct{ Error string }{msg})
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// gtfsRealtimeHandler republishes the entities of a type from all feeds as
+// a single GTFS-Realtime feed message, so consumers make one request
+// rather than one per MTA feed:
+//
+//	GET /gtfs-rt/trip-updates
+//	GET /gtfs-rt/vehicle-positions
+//	GET /gtfs-rt/alerts
+//
+// The message is encoded as protobuf, or as JSON with ?format=json.
+type gtfsRealtimeHandler struct {
+	client *mta.Client
+	t      mta.EntityType
+}
+
+func (h gtfsRealtimeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	version := time.Unix(0, 0)
+	if updated := h.client.Updated(); updated != nil {
+		version = *updated
+	}
+	if notModified(w, r, fmt.Sprintf(`W/"%x"`, version.UnixNano()), version, realtimeMaxAge) {
+		return
+	}
+
+	feed := h.client.GetFeedMessage(h.t)
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		m := jsonpb.Marshaler{OrigName: true, Indent: "  "}
+		if err := m.Marshal(w, feed); err != nil {
+			log.Println(err.Error())
+		}
+		return
+	}
+
+	data, err := proto.Marshal(feed)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, http.StatusText(500), 500)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(data)
+}
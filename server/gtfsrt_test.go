@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gtfs-realtime-bindings/golang/gtfs"
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+func TestGTFSRealtime(t *testing.T) {
+	h := gtfsRealtimeHandler{client: client(t), t: mta.TripUpdates}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/gtfs-rt/trip-updates", nil))
+	if got, want := w.Header().Get("Content-Type"), "application/x-protobuf"; got != want {
+		t.Errorf("Content-Type got %v, want %v", got, want)
+	}
+	var feed gtfs.FeedMessage
+	if err := proto.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := feed.GetHeader().GetGtfsRealtimeVersion(), "1.0"; got != want {
+		t.Errorf("gtfs_realtime_version got %v, want %v", got, want)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/gtfs-rt/trip-updates?format=json", nil))
+	var v struct {
+		Header struct {
+			Version string `json:"gtfs_realtime_version"`
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.Header.Version, "1.0"; got != want {
+		t.Errorf("gtfs_realtime_version got %v, want %v", got, want)
+	}
+
+	r := httptest.NewRequest("GET", "/gtfs-rt/trip-updates", nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got, want := w.Code, 304; got != want {
+		t.Errorf("status got %v, want %v", got, want)
+	}
+}
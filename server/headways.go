@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+const defaultGapThreshold = 5 * time.Minute
+
+// GetHeadwaysHandler returns the headways of a route at a station.
+type GetHeadwaysHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetHeadwaysParams defines the parameters of the GetHeadways RPC.
+type GetHeadwaysParams struct {
+	RouteID   string
+	StationID string
+	// Threshold is the number of seconds a gap may exceed the scheduled
+	// headway before it is flagged; defaults to five minutes.
+	Threshold int
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetHeadwaysHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetHeadwaysParams
+	if err := jsonrpc.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.RouteID == "" || p.Threshold < 0 {
+		return nil, jsonrpc.ErrInvalidParams()
+	}
+	threshold := defaultGapThreshold
+	if p.Threshold > 0 {
+		threshold = time.Duration(p.Threshold) * time.Second
+	}
+
+	headways, err := h.client.GetHeadways(p.RouteID, mta.StationID(p.StationID), threshold)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return GetHeadwaysResult{Headways: h.p.Headways(headways)}, nil
+}
+
+// GetHeadwaysResult describes the response of the GetHeadways RPC.
+type GetHeadwaysResult struct{ Headways []*protocol.Headways }
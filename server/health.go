@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+const (
+	// defaultMaxFeedAge is how long a feed may go without a successful
+	// fetch before it is stale.
+	defaultMaxFeedAge = 5 * time.Minute
+	// minFreshFeeds is the share of feeds that must be fresh for the server
+	// to be ready. The MTA often loses a single feed, which leaves the
+	// lines of the others worth serving.
+	minFreshFeeds = 0.5
+)
+
+// healthHandler reports that the process is alive.
+//
+//	GET /healthz
+type healthHandler struct{}
+
+func (healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, struct{ Status string }{"ok"})
+}
+
+// Readiness is the body of /readyz.
+type Readiness struct {
+	Ready bool
+	// Reasons lists why the server is not ready.
+	Reasons  []string `json:",omitempty"`
+	Stations int
+	Updated  *time.Time
+	Feeds    []*FeedReadiness
+	// Stale lists the feeds not fetched within the maximum age, whose
+	// arrivals are stale or missing even while the server is ready.
+	Stale []int `json:",omitempty"`
+}
+
+// FeedReadiness is the freshness of a feed.
+type FeedReadiness struct {
+	FeedID    int
+	Fetched   *time.Time
+	Timestamp *time.Time
+	// Age is the number of seconds since the feed was last fetched
+	// successfully.
+	Age   *int
+	Fresh bool
+}
+
+// readyHandler reports whether the server has data worth serving: the
+// static GTFS is loaded, the feeds were refreshed at least once, and at
+// least half of the feeds were fetched successfully within maxAge.
+//
+//	GET /readyz
+type readyHandler struct {
+	client *mta.Client
+	maxAge time.Duration
+}
+
+func (h readyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := readiness(h.client.NumStations(), h.client.Updated(), h.client.GetFeedStatus(), h.maxAge, time.Now())
+	status := http.StatusOK
+	if !v.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	writeHealth(w, status, v)
+}
+
+func readiness(stations int, updated *time.Time, feeds []*mta.FeedStatus, maxAge time.Duration, now time.Time) *Readiness {
+	v := &Readiness{Stations: stations, Updated: updated}
+	if stations == 0 {
+		v.Reasons = append(v.Reasons, "static GTFS not loaded")
+	}
+	if updated == nil {
+		v.Reasons = append(v.Reasons, "feeds not refreshed")
+	}
+	fresh := 0
+	for _, feed := range feeds {
+		f := &FeedReadiness{FeedID: feed.FeedID, Fetched: feed.Fetched, Timestamp: feed.Timestamp}
+		v.Feeds = append(v.Feeds, f)
+		if feed.Fetched != nil {
+			age := now.Sub(*feed.Fetched)
+			seconds := int(age.Seconds())
+			f.Age = &seconds
+			f.Fresh = age <= maxAge
+		}
+		if f.Fresh {
+			fresh++
+		} else {
+			v.Stale = append(v.Stale, feed.FeedID)
+		}
+	}
+	if float64(fresh) < minFreshFeeds*float64(len(feeds)) {
+		v.Reasons = append(v.Reasons, fmt.Sprintf("%d of %d feeds fetched within %v", fresh, len(feeds), maxAge))
+	}
+	v.Ready = len(v.Reasons) == 0
+	return v
+}
+
+func writeHealth(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
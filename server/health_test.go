@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+func TestReadiness(t *testing.T) {
+	now := time.Now()
+	recent, old := now.Add(-time.Minute), now.Add(-time.Hour)
+	feeds := func(fetched ...*time.Time) []*mta.FeedStatus {
+		var result []*mta.FeedStatus
+		for i, f := range fetched {
+			result = append(result, &mta.FeedStatus{FeedID: i + 1, Fetched: f})
+		}
+		return result
+	}
+
+	var tests = []struct {
+		name     string
+		stations int
+		updated  *time.Time
+		feeds    []*mta.FeedStatus
+		ready    bool
+		reasons  int
+		stale    int
+	}{
+		{"ready", 1, &recent, feeds(&recent, &recent), true, 0, 0},
+		{"no stations", 0, &recent, feeds(&recent), false, 1, 0},
+		{"not refreshed", 1, nil, feeds(nil, nil), false, 2, 2},
+		{"stale feed", 1, &recent, feeds(&recent, &old), true, 0, 1},
+		{"never fetched feed", 1, &recent, feeds(&recent, &recent, nil), true, 0, 1},
+		{"stale feeds", 1, &recent, feeds(&recent, &old, nil), false, 1, 2},
+	}
+	for _, tt := range tests {
+		got := readiness(tt.stations, tt.updated, tt.feeds, defaultMaxFeedAge, now)
+		if got.Ready != tt.ready || len(got.Reasons) != tt.reasons {
+			t.Errorf("%s: got %v %v, want %v and %d reasons", tt.name, got.Ready, got.Reasons, tt.ready, tt.reasons)
+		}
+		if len(got.Stale) != tt.stale {
+			t.Errorf("%s: Stale got %v, want %d feeds", tt.name, got.Stale, tt.stale)
+		}
+		if len(got.Feeds) != len(tt.feeds) {
+			t.Errorf("%s: len(Feeds) got %v, want %v", tt.name, len(got.Feeds), len(tt.feeds))
+		}
+	}
+}
+
+func TestHealthHandlers(t *testing.T) {
+	var tests = []struct {
+		path   string
+		h      http.Handler
+		status int
+	}{
+		{"/healthz", healthHandler{}, 200},
+		// The client has not refreshed the feeds.
+		{"/readyz", readyHandler{client: client(t), maxAge: defaultMaxFeedAge}, 503},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		tt.h.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status got %v, want %v", tt.path, w.Code, tt.status)
+		}
+		if got, want := w.Header().Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("%s: Content-Type got %v, want %v", tt.path, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// burstDuration is how many seconds of requests a client may make at
+	// once after being idle.
+	burstDuration = 5 * time.Second
+	// pruneInterval is how often buckets of idle clients are dropped.
+	pruneInterval = time.Minute
+)
+
+// bucket is the token bucket of a client.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter is a token-bucket rate limiter keyed by client. A limiter with a
+// rate of zero allows every request.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	mtx     sync.Mutex
+	buckets map[string]*bucket
+	pruned  time.Time
+}
+
+// newLimiter returns a limiter allowing rate requests per second.
+func newLimiter(rate float64) *limiter {
+	return &limiter{
+		rate:    rate,
+		burst:   math.Max(1, rate*burstDuration.Seconds()),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// exceedsBurst reports whether n requests at once are more than the limiter
+// ever allows.
+func (l *limiter) exceedsBurst(n int) bool {
+	return l.rate > 0 && float64(n) > l.burst
+}
+
+// allow takes n tokens from the bucket of key, or returns how long to wait
+// for them.
+func (l *limiter) allow(key string, n int, now time.Time) (bool, time.Duration) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if now.Sub(l.pruned) > pruneInterval {
+		l.prune(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < float64(n) {
+		return false, time.Duration((float64(n) - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens -= float64(n)
+	return true, 0
+}
+
+func (l *limiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+}
+
+// prune drops full buckets, which are indistinguishable from new ones.
+func (l *limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.pruned = now
+}
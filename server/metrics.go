@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+	"github.com/osamingo/jsonrpc"
+)
+
+var (
+	rpcDuration = metrics.Default.NewHistogramVec("mtapi_rpc_duration_seconds",
+		"Time to serve a JSON-RPC method.", metrics.DefBuckets, "method")
+	rpcErrors = metrics.Default.NewCounterVec("mtapi_rpc_errors_total",
+		"JSON-RPC method errors by code.", "method", "code")
+)
+
+// methodHandler counts the calls of a JSON-RPC method by consumer and
+// records their latency and errors.
+type methodHandler struct {
+	method string
+	h      jsonrpc.Handler
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h methodHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	usage.Inc(consumer(c), h.method)
+	start := time.Now()
+	result, err := h.h.ServeJSONRPC(c, params)
+	rpcDuration.Observe(time.Since(start).Seconds(), h.method)
+	if err != nil {
+		rpcErrors.Inc(h.method, strconv.Itoa(int(err.Code)))
+	}
+	return result, err
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/alecthomas/jsonschema"
+)
+
+const openAPIVersion = "3.0.2"
+
+type openAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openRPCInfo                      `json:"info"`
+	Paths      map[string]map[string]*openAPIOp `json:"paths"`
+	Components openRPCComponents                `json:"components"`
+}
+
+type openAPIOp struct {
+	Summary     string                      `json:"summary"`
+	Description string                      `json:"description,omitempty"`
+	Parameters  []*openAPIParam             `json:"parameters,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParam struct {
+	Name        string           `json:"name"`
+	In          string           `json:"in"`
+	Description string           `json:"description,omitempty"`
+	Required    bool             `json:"required,omitempty"`
+	Schema      *jsonschema.Type `json:"schema"`
+	Example     interface{}      `json:"example,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonschema.Type `json:"schema"`
+}
+
+var (
+	stringSchema = &jsonschema.Type{Type: "string"}
+	intSchema    = &jsonschema.Type{Type: "integer"}
+	numberSchema = &jsonschema.Type{Type: "number"}
+)
+
+// arrivalQuery documents the arrival filters of the station routes.
+var arrivalQuery = []*openAPIParam{
+	{Name: "routes", In: "query", Description: "Comma-separated route IDs to restrict arrivals to.", Schema: stringSchema, Example: "Q,N"},
+	{Name: "directions", In: "query", Description: `Comma-separated directions to restrict arrivals to, "N" or "S".`, Schema: stringSchema, Example: "S"},
+	{Name: "limit", In: "query", Description: paramDocs["Limit"], Schema: intSchema, Example: 3},
+	{Name: "within", In: "query", Description: paramDocs["Within"], Schema: intSchema, Example: 600},
+}
+
+// openAPI generates the OpenAPI document of the REST API.
+func openAPI(release string) *openAPIDocument {
+	s := newSchemas()
+	ok := func(v interface{}) map[string]*openAPIResponse {
+		return map[string]*openAPIResponse{
+			"200": {
+				Description: "OK",
+				Content:     map[string]*openAPIMediaType{"application/json": {Schema: s.reflect(v, false)}},
+			},
+			"304": {Description: "Not Modified; the ETag given in If-None-Match is current."},
+		}
+	}
+	withErrors := func(r map[string]*openAPIResponse) map[string]*openAPIResponse {
+		r["400"] = &openAPIResponse{Description: "Invalid parameters."}
+		r["404"] = &openAPIResponse{Description: "Station not found."}
+		return r
+	}
+
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openRPCInfo{
+			Title:       "mtapi",
+			Description: "Read-only REST API for the MTA API; responses carry an ETag and Last-Modified.",
+			Version:     release,
+		},
+		Paths: map[string]map[string]*openAPIOp{
+			"/api/v1/stations": {"get": {
+				Summary:   methodDocs["GetStations"].Summary,
+				Responses: ok(GetStationsResult{}),
+			}},
+			"/api/v1/stations/{id}": {"get": {
+				Summary: methodDocs["GetStation"].Summary,
+				Parameters: append([]*openAPIParam{
+					{Name: "id", In: "path", Description: paramDocs["ID"], Required: true, Schema: stringSchema, Example: "L03"},
+				}, arrivalQuery...),
+				Responses: withErrors(ok(GetStationResult{})),
+			}},
+			"/api/v1/closest": {"get": {
+				Summary:     methodDocs["GetClosestStations"].Summary,
+				Description: methodDocs["GetClosestStations"].Description,
+				Parameters: append([]*openAPIParam{
+					{Name: "lat", In: "query", Description: paramDocs["Lat"], Required: true, Schema: numberSchema, Example: 40.7347908},
+					{Name: "lon", In: "query", Description: paramDocs["Lon"], Required: true, Schema: numberSchema, Example: -73.9907299},
+					{Name: "n", In: "query", Description: paramDocs["NumStations"], Schema: intSchema, Example: 3},
+				}, arrivalQuery...),
+				Responses: withErrors(ok(GetClosestResult{})),
+			}},
+			"/api/v1/status": {"get": {
+				Summary:   methodDocs["GetSystemStatus"].Summary,
+				Responses: ok(GetSystemStatusResult{}),
+			}},
+			"/api/v1/routes": {"get": {
+				Summary:   methodDocs["GetRoutes"].Summary,
+				Responses: ok(GetRoutesResult{}),
+			}},
+		},
+	}
+	doc.Components.Schemas = s.definitions
+	return doc
+}
+
+// openAPIHandler serves the OpenAPI document.
+type openAPIHandler struct {
+	release string
+
+	once sync.Once
+	doc  *openAPIDocument
+}
+
+func (h *openAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() { h.doc = openAPI(h.release) })
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.doc)
+}
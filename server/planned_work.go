@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+// defaultPlannedWorkWindow is how far ahead planned work is returned by
+// default.
+const defaultPlannedWorkWindow = 7 * 24 * time.Hour
+
+// GetPlannedWorkHandler returns the planned work and service changes
+// affecting routes over a span of time.
+type GetPlannedWorkHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetPlannedWorkParams defines the parameters of the GetPlannedWork RPC.
+type GetPlannedWorkParams struct {
+	Routes []string
+	// From defaults to now, and To to a week after From.
+	From *time.Time
+	To   *time.Time
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetPlannedWorkHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetPlannedWorkParams
+	if params != nil {
+		if err := jsonrpc.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	from := time.Now().UTC()
+	if p.From != nil {
+		from = *p.From
+	}
+	to := from.Add(defaultPlannedWorkWindow)
+	if p.To != nil {
+		to = *p.To
+	}
+	if !to.After(from) {
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidParams,
+			Message: "To must be after From",
+		}
+	}
+	return GetPlannedWorkResult{PlannedWork: h.p.PlannedWork(h.client.GetPlannedWork(p.Routes, from, to))}, nil
+}
+
+// GetPlannedWorkResult describes the response of the GetPlannedWork RPC.
+type GetPlannedWorkResult struct{ PlannedWork []*protocol.PlannedWork }
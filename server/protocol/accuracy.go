@@ -0,0 +1,33 @@
+package protocol
+
+import "github.com/jeffreylo/mtapi/mta"
+
+// Accuracy is the distribution of prediction error in seconds for a route
+// and lookahead bucket.
+type Accuracy struct {
+	RouteID string
+	Bucket  string
+	Count   int
+	Mean    int
+	P10     int
+	P50     int
+	P90     int
+	OnTime  float64
+}
+
+func (p *Protocol) Accuracy(v []*mta.Accuracy) []*Accuracy {
+	result := make([]*Accuracy, 0, len(v))
+	for _, a := range v {
+		result = append(result, &Accuracy{
+			RouteID: a.RouteID,
+			Bucket:  a.Bucket,
+			Count:   a.Count,
+			Mean:    seconds(a.Mean),
+			P10:     seconds(a.P10),
+			P50:     seconds(a.P50),
+			P90:     seconds(a.P90),
+			OnTime:  a.OnTime,
+		})
+	}
+	return result
+}
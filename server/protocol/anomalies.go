@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// Anomaly is an irregularity observed in the realtime feeds.
+type Anomaly struct {
+	Kind      string
+	RouteID   string
+	TripIDs   []string
+	StationID string     `json:",omitempty"`
+	Direction string     `json:",omitempty"`
+	Time      *time.Time `json:",omitempty"`
+	Detected  *time.Time
+}
+
+func (p *Protocol) Anomalies(v []*mta.Anomaly) []*Anomaly {
+	result := make([]*Anomaly, 0, len(v))
+	for _, a := range v {
+		result = append(result, &Anomaly{
+			Kind:      string(a.Kind),
+			RouteID:   a.RouteID,
+			TripIDs:   a.TripIDs,
+			StationID: string(a.StationID),
+			Direction: string(a.Direction),
+			Time:      a.Time,
+			Detected:  a.Detected,
+		})
+	}
+	return result
+}
@@ -0,0 +1,8 @@
+package protocol
+
+import "time"
+
+// NewAt returns a Protocol whose clock is stopped at now.
+func NewAt(now time.Time) *Protocol {
+	return &Protocol{now: func() time.Time { return now }}
+}
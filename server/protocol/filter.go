@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// Filter restricts the arrivals returned for a station. The zero value
+// matches every arrival.
+type Filter struct {
+	// Routes limits arrivals to the given route IDs, e.g., "Q" or "S".
+	Routes []string
+	// Directions limits arrivals to the given directions, i.e., "N" or "S".
+	Directions []mta.Direction
+	// Limit is the maximum number of arrivals per direction.
+	Limit int
+	// Within is the time horizon beyond which arrivals are dropped.
+	Within time.Duration
+}
+
+func (f *Filter) matchDirection(d mta.Direction) bool {
+	if f == nil || len(f.Directions) == 0 {
+		return true
+	}
+	for _, v := range f.Directions {
+		if v == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchRoute(routeID string) bool {
+	if f == nil || len(f.Routes) == 0 {
+		return true
+	}
+	for _, v := range f.Routes {
+		if v == routeID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchTime(t *time.Time, now time.Time) bool {
+	if t == nil {
+		return true
+	}
+	// Arrivals that have passed are dropped, filter or not, as the feed
+	// that predicted them may not have been refreshed since.
+	if t.Before(now) {
+		return false
+	}
+	return f == nil || f.Within <= 0 || t.Before(now.Add(f.Within))
+}
+
+func (f *Filter) full(n int) bool {
+	return f != nil && f.Limit > 0 && n >= f.Limit
+}
+
+// arrivals returns the arrivals of a direction that match.
+func (f *Filter) arrivals(s []*mta.Arrival, now time.Time) []*mta.Arrival {
+	var matched []*mta.Arrival
+	for _, u := range s {
+		if f.full(len(matched)) {
+			break
+		}
+		if !f.matchRoute(mta.PublicRouteID(u.RouteID)) || !f.matchTime(u.Time, now) {
+			continue
+		}
+		matched = append(matched, u)
+	}
+	return matched
+}
@@ -0,0 +1,174 @@
+package protocol_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/jeffreylo/mtapi/server/protocol/v2"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var t0 = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) *time.Time {
+	t := t0.Add(d)
+	return &t
+}
+
+// fixtures returns mta values covering every field of the protocol.
+func fixtures() (*mta.Station, []*mta.Route, *mta.Service, []*mta.Headways, []*mta.Anomaly, []*mta.Accuracy, []*mta.PlannedWork, []*mta.StatusChange, []*mta.StatusStats) {
+	station := &mta.Station{
+		ID:          "L03",
+		Name:        "Union Sq - 14 St",
+		Coordinates: &mta.Coordinates{Lat: 40.734789, Lon: -73.99073},
+		Arrivals: map[mta.Direction][]*mta.Arrival{
+			"N": {
+				{TripID: "n1", RouteID: "Q", Time: at(time.Minute), StartDate: "20180601", Updated: at(-30 * time.Second), StaleAt: *at(90 * time.Second)},
+				{TripID: "n2", RouteID: "GS", Time: at(3 * time.Minute), StartDate: "20180601", Updated: at(-30 * time.Second), StaleAt: *at(90 * time.Second)},
+			},
+			"S": {
+				{TripID: "s1", RouteID: "Q", Time: at(2 * time.Minute), StartDate: "20180601", Updated: at(-30 * time.Second), StaleAt: *at(90 * time.Second)},
+				// The feed of a stale arrival has not updated it since
+				// before its stale-after.
+				{TripID: "s2", RouteID: "Q", Time: at(4 * time.Minute), StartDate: "20180601", Updated: at(-3 * time.Minute), StaleAt: *at(-time.Minute)},
+			},
+		},
+		Updated: at(0),
+	}
+	routes := []*mta.Route{
+		{ID: "Q", ShortName: "Q", LongName: "Broadway Express", Description: "Trains operate between 96 St and Coney Island.", Color: "FCCC0A", TextColor: "000000", URL: "http://web.mta.info/nyct/service/pdf/tqcur.pdf"},
+		{ID: "GS", ShortName: "S", LongName: "42 St Shuttle", Description: "Operates between Times Sq and Grand Central."},
+	}
+	service := &mta.Service{
+		Updated: at(-time.Minute),
+		Status: []*mta.Status{
+			{Line: "NQR", Routes: []string{"N", "Q", "R"}, Category: mta.StatusGoodService},
+			{Line: "L", Routes: []string{"L"}, Category: mta.StatusSuspended, Description: "Suspended\n[L] trains are suspended in both directions.", Posted: at(-10 * time.Minute)},
+		},
+	}
+	headways := []*mta.Headways{{
+		RouteID:   "Q",
+		StationID: "L03",
+		Direction: "S",
+		Predicted: []time.Duration{4 * time.Minute, 15 * time.Minute},
+		Scheduled: 6 * time.Minute,
+		Gaps: []*mta.Gap{{
+			FromTripID: "s1",
+			ToTripID:   "s2",
+			From:       at(2 * time.Minute),
+			To:         at(17 * time.Minute),
+			Duration:   15 * time.Minute,
+		}},
+	}}
+	anomalies := []*mta.Anomaly{
+		{Kind: mta.AnomalyGhost, RouteID: "Q", TripIDs: []string{"n3"}, StationID: "L03", Direction: "N", Time: at(5 * time.Minute), Detected: at(0)},
+		{Kind: mta.AnomalyBunching, RouteID: "Q", TripIDs: []string{"s1", "s2"}, Detected: at(0)},
+	}
+	accuracy := []*mta.Accuracy{{
+		RouteID: "Q",
+		Bucket:  "0-2",
+		Count:   120,
+		Mean:    12 * time.Second,
+		P10:     -30 * time.Second,
+		P50:     10 * time.Second,
+		P90:     55 * time.Second,
+		OnTime:  0.85,
+	}}
+	plannedWork := []*mta.PlannedWork{{
+		ID:          "status:ACE",
+		Category:    mta.StatusPlannedWork,
+		Routes:      []string{"E"},
+		Stations:    []mta.StationID{"G08", "G21"},
+		Header:      "Planned Work",
+		Description: "[E] trains run local between 71 Av and Queens Plaza, 9:45 PM to 5 AM, Mon to Fri, Jun 11 - 15.",
+		Periods:     []*mta.Period{{Start: at(10 * time.Hour), End: at(17*time.Hour + 15*time.Minute)}, {Start: at(-time.Hour)}},
+	}}
+	statusChanges := []*mta.StatusChange{{
+		Line:        "456",
+		Routes:      []string{"4", "5", "5X", "6", "6X"},
+		Category:    mta.StatusDelays,
+		Previous:    mta.StatusGoodService,
+		Time:        *at(-12 * time.Minute),
+		Description: "Northbound [4], [5] and [6] trains are running with delays.",
+	}}
+	statusStats := []*mta.StatusStats{{
+		Line: "456",
+		Date: "2018-06-13",
+		Durations: map[mta.StatusCategory]time.Duration{
+			mta.StatusGoodService: 7*time.Hour + 48*time.Minute,
+			mta.StatusDelays:      12*time.Minute + 20*time.Second,
+		},
+	}}
+	return station, routes, service, headways, anomalies, accuracy, plannedWork, statusChanges, statusStats
+}
+
+func TestGolden(t *testing.T) {
+	station, routes, service, headways, anomalies, accuracy, plannedWork, statusChanges, statusStats := fixtures()
+	p := protocol.NewAt(t0)
+
+	s1 := p.Station(station, nil)
+	stations1 := p.Stations(mta.Stations{station.ID: station})
+	routes1 := p.Routes(routes)
+	service1 := p.Service(service)
+	headways1 := p.Headways(headways)
+	anomalies1 := p.Anomalies(anomalies)
+	accuracy1 := p.Accuracy(accuracy)
+	plannedWork1 := p.PlannedWork(plannedWork)
+	statusChanges1 := p.StatusChanges(statusChanges)
+	statusStats1 := p.StatusStats(statusStats)
+
+	var tests = []struct {
+		name string
+		v    interface{}
+	}{
+		{"v1/station", s1},
+		{"v1/stations", stations1},
+		{"v1/routes", routes1},
+		{"v1/service", service1},
+		{"v1/headways", headways1},
+		{"v1/anomalies", anomalies1},
+		{"v1/accuracy", accuracy1},
+		{"v1/plannedwork", plannedWork1},
+		{"v1/statushistory", statusChanges1},
+		{"v1/statusstats", statusStats1},
+		{"v2/station", v2.StationResult{Station: v2.NewStation(s1)}},
+		{"v2/stations", v2.StationsResult{Stations: v2.NewStations(stations1)}},
+		{"v2/routes", v2.RoutesResult{Routes: v2.NewRoutes(routes1)}},
+		{"v2/service", v2.ServiceResult{Service: v2.NewService(service1)}},
+		{"v2/headways", v2.HeadwaysResult{Headways: v2.NewHeadways(headways1)}},
+		{"v2/anomalies", v2.AnomaliesResult{Anomalies: v2.NewAnomalies(anomalies1)}},
+		{"v2/accuracy", v2.AccuracyResult{Accuracy: v2.NewAccuracy(accuracy1)}},
+		{"v2/plannedwork", v2.PlannedWorkResult{PlannedWork: v2.NewPlannedWork(plannedWork1)}},
+		{"v2/statushistory", v2.StatusHistoryResult{Changes: v2.NewStatusChanges(statusChanges1)}},
+		{"v2/statusstats", v2.StatusStatsResult{Stats: v2.NewStatusStats(statusStats1)}},
+	}
+	for _, tt := range tests {
+		got, err := json.MarshalIndent(tt.v, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, '\n')
+		path := filepath.Join("testdata", tt.name+".json")
+		if *update {
+			if err := ioutil.WriteFile(path, got, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// Headways describes the spacing of trains in seconds.
+type Headways struct {
+	RouteID   string
+	StationID string
+	Direction string
+	Predicted []int
+	Scheduled int `json:",omitempty"`
+	Gaps      []*Gap
+}
+
+// Gap is a service gap between two consecutive trains.
+type Gap struct {
+	FromTripID string
+	ToTripID   string
+	From       *time.Time
+	To         *time.Time
+	Duration   int
+}
+
+func seconds(d time.Duration) int {
+	return int(d / time.Second)
+}
+
+func (p *Protocol) Headways(v []*mta.Headways) []*Headways {
+	result := make([]*Headways, 0, len(v))
+	for _, h := range v {
+		predicted := make([]int, 0, len(h.Predicted))
+		for _, d := range h.Predicted {
+			predicted = append(predicted, seconds(d))
+		}
+		gaps := make([]*Gap, 0, len(h.Gaps))
+		for _, g := range h.Gaps {
+			gaps = append(gaps, &Gap{
+				FromTripID: g.FromTripID,
+				ToTripID:   g.ToTripID,
+				From:       g.From,
+				To:         g.To,
+				Duration:   seconds(g.Duration),
+			})
+		}
+		result = append(result, &Headways{
+			RouteID:   h.RouteID,
+			StationID: string(h.StationID),
+			Direction: string(h.Direction),
+			Predicted: predicted,
+			Scheduled: seconds(h.Scheduled),
+			Gaps:      gaps,
+		})
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// PlannedWork is work or a service change affecting routes over periods
+// of time.
+type PlannedWork struct {
+	ID          string
+	Category    string
+	Routes      []string
+	Stations    []string `json:",omitempty"`
+	Header      string
+	Description string `json:",omitempty"`
+	Periods     []*Period
+}
+
+// Period is a span of time; a missing start or end is unbounded.
+type Period struct {
+	Start *time.Time `json:",omitempty"`
+	End   *time.Time `json:",omitempty"`
+}
+
+func (p *Protocol) PlannedWork(v []*mta.PlannedWork) []*PlannedWork {
+	result := make([]*PlannedWork, 0, len(v))
+	for _, w := range v {
+		var stations []string
+		for _, id := range w.Stations {
+			stations = append(stations, string(id))
+		}
+		periods := make([]*Period, 0, len(w.Periods))
+		for _, p := range w.Periods {
+			periods = append(periods, &Period{Start: p.Start, End: p.End})
+		}
+		result = append(result, &PlannedWork{
+			ID:          w.ID,
+			Category:    string(w.Category),
+			Routes:      w.Routes,
+			Stations:    stations,
+			Header:      w.Header,
+			Description: w.Description,
+			Periods:     periods,
+		})
+	}
+	return result
+}
@@ -1,7 +1,22 @@
+// Package protocol defines version 1 of the API contract: the types
+// responses are encoded from and their translation from the mta package.
+// The JSON encoding of these types is pinned by the golden files in
+// testdata/v1. Existing fields must not change or be removed, but fields
+// may be added, as Age and Stale were to Arrival and Category, Routes,
+// Description and Posted to Status; clients must ignore fields they do not
+// know. See package v2 for its successor.
 package protocol
 
-type Protocol struct{}
+import "time"
 
+// Protocol translates mta values to protocol values.
+type Protocol struct {
+	// now returns the time arrivals are translated at, which drops those
+	// that have passed.
+	now func() time.Time
+}
+
+// New returns a Protocol.
 func New() *Protocol {
-	return &Protocol{}
+	return &Protocol{now: time.Now}
 }
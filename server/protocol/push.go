@@ -0,0 +1,11 @@
+package protocol
+
+// Notification is the payload of a Web Push message, shown by the client's
+// service worker.
+type Notification struct {
+	Title string
+	Body  string
+	// Tag identifies what the notification is about, so that it replaces
+	// an earlier notification about the same.
+	Tag string
+}
@@ -0,0 +1,29 @@
+package protocol
+
+import "github.com/jeffreylo/mtapi/mta"
+
+type Route struct {
+	ID          string
+	ShortName   string
+	LongName    string
+	Description string
+	Color       string `json:",omitempty"`
+	TextColor   string `json:",omitempty"`
+	URL         string `json:",omitempty"`
+}
+
+func (p *Protocol) Routes(v []*mta.Route) []*Route {
+	result := make([]*Route, 0, len(v))
+	for _, r := range v {
+		result = append(result, &Route{
+			ID:          r.ID,
+			ShortName:   r.ShortName,
+			LongName:    r.LongName,
+			Description: r.Description,
+			Color:       r.Color,
+			TextColor:   r.TextColor,
+			URL:         r.URL,
+		})
+	}
+	return result
+}
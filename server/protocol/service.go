@@ -0,0 +1,39 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// Service is the service status of the subway.
+type Service struct {
+	Updated *time.Time
+	Status  []*Status
+}
+
+// Status is the service status of a line.
+type Status struct {
+	Line     string
+	OK       bool
+	Category string
+	Routes   []string
+	// Description is the text of the status, if other than good service.
+	Description string     `json:",omitempty"`
+	Posted      *time.Time `json:",omitempty"`
+}
+
+func (p *Protocol) Service(v *mta.Service) *Service {
+	status := make([]*Status, 0, len(v.Status))
+	for _, s := range v.Status {
+		status = append(status, &Status{
+			Line:        s.Line,
+			OK:          s.OK(),
+			Category:    string(s.Category),
+			Routes:      s.Routes,
+			Description: s.Description,
+			Posted:      s.Posted,
+		})
+	}
+	return &Service{Updated: v.Updated, Status: status}
+}
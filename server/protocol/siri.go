@@ -0,0 +1,130 @@
+package protocol
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+const (
+	siriNamespace = "http://www.siri.org.uk/siri"
+	// siriOperatorRef is the agency operating the subway, which prefixes
+	// line references as in MTA Bus Time.
+	siriOperatorRef = "MTA NYCT"
+	// siriValidity is how long a delivery is valid; the feeds refresh every
+	// few seconds.
+	siriValidity = 30 * time.Second
+)
+
+// Siri is the root of a SIRI response.
+type Siri struct {
+	XMLName         xml.Name `json:"-" xml:"Siri"`
+	XMLNS           string   `json:"-" xml:"xmlns,attr"`
+	ServiceDelivery *ServiceDelivery
+}
+
+// ServiceDelivery contains the deliveries of a SIRI response.
+type ServiceDelivery struct {
+	ResponseTimestamp      time.Time
+	StopMonitoringDelivery []*StopMonitoringDelivery
+}
+
+// StopMonitoringDelivery contains the upcoming visits at a stop.
+type StopMonitoringDelivery struct {
+	ResponseTimestamp  time.Time
+	ValidUntil         time.Time
+	MonitoredStopVisit []*MonitoredStopVisit
+}
+
+// MonitoredStopVisit is the visit of a vehicle to the monitored stop.
+type MonitoredStopVisit struct {
+	RecordedAtTime          time.Time
+	MonitoringRef           string
+	MonitoredVehicleJourney *MonitoredVehicleJourney
+}
+
+// MonitoredVehicleJourney describes the trip of a visit.
+type MonitoredVehicleJourney struct {
+	LineRef                 string
+	DirectionRef            string
+	FramedVehicleJourneyRef *FramedVehicleJourneyRef
+	PublishedLineName       string
+	OperatorRef             string
+	MonitoredCall           *MonitoredCall
+}
+
+// FramedVehicleJourneyRef identifies a trip on a service date.
+type FramedVehicleJourneyRef struct {
+	DataFrameRef           string
+	DatedVehicleJourneyRef string
+}
+
+// MonitoredCall describes the call of a trip at the monitored stop.
+type MonitoredCall struct {
+	StopPointRef        string
+	StopPointName       string
+	ExpectedArrivalTime *time.Time `json:",omitempty" xml:",omitempty"`
+}
+
+// siriDirections orders directions by their GTFS direction ID.
+var siriDirections = []mta.Direction{"N", "S"}
+
+// StopMonitoring translates the arrivals of a station to a SIRI
+// StopMonitoringDelivery. Directions map to GTFS direction IDs, i.e., "0"
+// for northbound and "1" for southbound.
+func (p *Protocol) StopMonitoring(v *mta.Station, f *Filter, now time.Time) *Siri {
+	recorded := now
+	if v.Updated != nil {
+		recorded = *v.Updated
+	}
+	delivery := &StopMonitoringDelivery{
+		ResponseTimestamp:  now,
+		ValidUntil:         now.Add(siriValidity),
+		MonitoredStopVisit: []*MonitoredStopVisit{},
+	}
+	for i, d := range siriDirections {
+		if !f.matchDirection(d) {
+			continue
+		}
+		for _, u := range f.arrivals(v.Arrivals[d], now) {
+			routeID := mta.PublicRouteID(u.RouteID)
+			delivery.MonitoredStopVisit = append(delivery.MonitoredStopVisit, &MonitoredStopVisit{
+				RecordedAtTime: recorded,
+				MonitoringRef:  string(v.ID),
+				MonitoredVehicleJourney: &MonitoredVehicleJourney{
+					LineRef:      siriOperatorRef + "_" + routeID,
+					DirectionRef: strconv.Itoa(i),
+					FramedVehicleJourneyRef: &FramedVehicleJourneyRef{
+						DataFrameRef:           siriDate(u.StartDate),
+						DatedVehicleJourneyRef: u.TripID,
+					},
+					PublishedLineName: routeID,
+					OperatorRef:       siriOperatorRef,
+					MonitoredCall: &MonitoredCall{
+						StopPointRef:        string(v.ID),
+						StopPointName:       v.Name,
+						ExpectedArrivalTime: u.Time,
+					},
+				},
+			})
+		}
+	}
+	return &Siri{
+		XMLNS: siriNamespace,
+		ServiceDelivery: &ServiceDelivery{
+			ResponseTimestamp:      now,
+			StopMonitoringDelivery: []*StopMonitoringDelivery{delivery},
+		},
+	}
+}
+
+// siriDate formats a GTFS date, e.g., 20180601, as an ISO 8601 date.
+func siriDate(date string) string {
+	t, err := time.Parse("20060102", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2006-01-02")
+}
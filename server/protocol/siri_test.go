@@ -0,0 +1,57 @@
+package protocol
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+func TestStopMonitoring(t *testing.T) {
+	now := time.Now().UTC()
+	station := &mta.Station{ID: "L03", Name: "Union Sq - 14 St", Arrivals: arrivals(now)}
+	station.Arrivals["S"][1].StartDate = "20180601"
+
+	siri := New().StopMonitoring(station, &Filter{Routes: []string{"Q", "S"}, Limit: 2}, now)
+	visits := siri.ServiceDelivery.StopMonitoringDelivery[0].MonitoredStopVisit
+
+	var tests = []struct {
+		tripID, lineRef, directionRef, dataFrameRef string
+	}{
+		{"n1", "MTA NYCT_Q", "0", ""},
+		{"n3", "MTA NYCT_Q", "0", ""},
+		{"s1", "MTA NYCT_S", "1", ""},
+		{"s2", "MTA NYCT_Q", "1", "2018-06-01"},
+	}
+	if len(visits) != len(tests) {
+		t.Fatalf("visits got %v, want %v", len(visits), len(tests))
+	}
+	for i, tt := range tests {
+		j := visits[i].MonitoredVehicleJourney
+		if got := j.FramedVehicleJourneyRef.DatedVehicleJourneyRef; got != tt.tripID {
+			t.Errorf("[%d] DatedVehicleJourneyRef got %v, want %v", i, got, tt.tripID)
+		}
+		if j.LineRef != tt.lineRef {
+			t.Errorf("[%d] LineRef got %v, want %v", i, j.LineRef, tt.lineRef)
+		}
+		if j.DirectionRef != tt.directionRef {
+			t.Errorf("[%d] DirectionRef got %v, want %v", i, j.DirectionRef, tt.directionRef)
+		}
+		if got := j.FramedVehicleJourneyRef.DataFrameRef; got != tt.dataFrameRef {
+			t.Errorf("[%d] DataFrameRef got %v, want %v", i, got, tt.dataFrameRef)
+		}
+		if got := j.MonitoredCall.StopPointName; got != station.Name {
+			t.Errorf("[%d] StopPointName got %v, want %v", i, got, station.Name)
+		}
+	}
+
+	b, err := xml.Marshal(siri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<Siri xmlns="http://www.siri.org.uk/siri"><ServiceDelivery>`; !strings.HasPrefix(string(b), want) {
+		t.Errorf("got %s, want prefix %s", b[:len(want)], want)
+	}
+}
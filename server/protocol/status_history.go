@@ -0,0 +1,52 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+// StatusChange is a line entering a status category.
+type StatusChange struct {
+	Line        string
+	Routes      []string
+	Category    string
+	Previous    string `json:",omitempty"`
+	Time        time.Time
+	Description string `json:",omitempty"`
+}
+
+// StatusStats is the minutes a line spent in each status category on a
+// day in New York.
+type StatusStats struct {
+	Line    string
+	Date    string
+	Minutes map[string]int
+}
+
+func (p *Protocol) StatusChanges(v []*mta.StatusChange) []*StatusChange {
+	result := make([]*StatusChange, 0, len(v))
+	for _, c := range v {
+		result = append(result, &StatusChange{
+			Line:        c.Line,
+			Routes:      c.Routes,
+			Category:    string(c.Category),
+			Previous:    string(c.Previous),
+			Time:        c.Time,
+			Description: c.Description,
+		})
+	}
+	return result
+}
+
+func (p *Protocol) StatusStats(v []*mta.StatusStats) []*StatusStats {
+	result := make([]*StatusStats, 0, len(v))
+	for _, s := range v {
+		minutes := make(map[string]int, len(s.Durations))
+		for category, d := range s.Durations {
+			minutes[string(category)] = int((d + time.Minute/2) / time.Minute)
+		}
+		result = append(result, &StatusStats{Line: s.Line, Date: s.Date, Minutes: minutes})
+	}
+	return result
+}
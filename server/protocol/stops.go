@@ -1,54 +1,69 @@
 package protocol
 
 import (
-	"strings"
 	"time"
 
 	"github.com/jeffreylo/mtapi/mta"
 )
 
+// Coordinates is the location of a station.
 type Coordinates struct {
 	Lat float64
 	Lon float64
 }
 
+// Arrival is the predicted arrival of a train. Age is the number of
+// seconds since its feed published the prediction, and Stale is set once
+// the feed has not updated it for too long, e.g., because the feed is
+// down.
 type Arrival struct {
 	TripID  string
 	Time    *time.Time
 	RouteID string
+	Age     *int `json:",omitempty"`
+	Stale   bool `json:",omitempty"`
 }
 
-type Arrivals map[mta.Direction][]*Arrival
+// Arrivals are the arrivals at a station by direction, "N" or "S".
+type Arrivals map[string][]*Arrival
 
+// Station is a station and, when requested, its upcoming arrivals.
 type Station struct {
 	ID          string
 	Name        string
 	Coordinates *Coordinates
-	Arrivals    map[mta.Direction][]*Arrival `json:",omitempty"`
-	Updated     *time.Time                   `json:",omitempty"`
+	Arrivals    Arrivals   `json:",omitempty"`
+	Updated     *time.Time `json:",omitempty"`
 }
 
-func (p *Protocol) Arrivals(v map[mta.Direction][]*mta.Arrival) Arrivals {
+func (p *Protocol) Arrivals(v map[mta.Direction][]*mta.Arrival, f *Filter) Arrivals {
+	now := p.now().UTC()
 	w := make(Arrivals)
 	for d, s := range v {
-		vv := make([]*Arrival, 0, len(s))
-		for _, u := range s {
-			routeID := u.RouteID
-			if strings.HasSuffix(routeID, "S") {
-				routeID = "S"
-			}
-			vv = append(vv, &Arrival{
+		if !f.matchDirection(d) {
+			continue
+		}
+		matched := f.arrivals(s, now)
+		vv := make([]*Arrival, 0, len(matched))
+		for _, u := range matched {
+			a := &Arrival{
 				TripID:  u.TripID,
 				Time:    u.Time,
-				RouteID: routeID,
-			})
+				RouteID: mta.PublicRouteID(u.RouteID),
+				Stale:   u.Stale(now),
+			}
+			if u.Updated != nil {
+				age := int(u.Age(now).Seconds())
+				a.Age = &age
+			}
+			vv = append(vv, a)
 		}
-		w[d] = vv
+		w[string(d)] = vv
 	}
 	return w
 }
 
-func (p *Protocol) Station(v *mta.Station) *Station {
+func (p *Protocol) Station(v *mta.Station, f *Filter) *Station {
 	return &Station{
 		ID:   string(v.ID),
 		Name: v.Name,
@@ -56,7 +71,8 @@ func (p *Protocol) Station(v *mta.Station) *Station {
 			Lat: v.Coordinates.Lat,
 			Lon: v.Coordinates.Lon,
 		},
-		Arrivals: p.Arrivals(v.Arrivals),
+		Arrivals: p.Arrivals(v.Arrivals, f),
+		Updated:  v.Updated,
 	}
 }
 
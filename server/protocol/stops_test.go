@@ -0,0 +1,82 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+)
+
+func arrivals(now time.Time) map[mta.Direction][]*mta.Arrival {
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	return map[mta.Direction][]*mta.Arrival{
+		"N": {
+			// n0 has passed, but its feed was not refreshed since.
+			{TripID: "n0", RouteID: "Q", Time: at(-1)},
+			{TripID: "n1", RouteID: "Q", Time: at(1)},
+			{TripID: "n2", RouteID: "N", Time: at(3)},
+			{TripID: "n3", RouteID: "Q", Time: at(8)},
+			{TripID: "n4", RouteID: "Q", Time: at(20)},
+		},
+		"S": {
+			{TripID: "s1", RouteID: "GS", Time: at(2)},
+			{TripID: "s2", RouteID: "Q", Time: at(4)},
+		},
+	}
+}
+
+func TestArrivalsFilter(t *testing.T) {
+	var tests = []struct {
+		name   string
+		filter *Filter
+		want   map[string][]string
+	}{
+		{"nil", nil, map[string][]string{"N": {"n1", "n2", "n3", "n4"}, "S": {"s1", "s2"}}},
+		{"routes", &Filter{Routes: []string{"Q"}}, map[string][]string{"N": {"n1", "n3", "n4"}, "S": {"s2"}}},
+		{"shuttle", &Filter{Routes: []string{"S"}}, map[string][]string{"N": {}, "S": {"s1"}}},
+		{"directions", &Filter{Directions: []mta.Direction{"S"}}, map[string][]string{"S": {"s1", "s2"}}},
+		{"limit", &Filter{Routes: []string{"Q"}, Limit: 2}, map[string][]string{"N": {"n1", "n3"}, "S": {"s2"}}},
+		{"within", &Filter{Within: 5 * time.Minute}, map[string][]string{"N": {"n1", "n2"}, "S": {"s1", "s2"}}},
+	}
+
+	p := New()
+	now := time.Now().UTC()
+	for _, tt := range tests {
+		got := p.Arrivals(arrivals(now), tt.filter)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v directions, want %v", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for d, ids := range tt.want {
+			if len(got[d]) != len(ids) {
+				t.Errorf("%s: %s got %v arrivals, want %v", tt.name, d, len(got[d]), len(ids))
+				continue
+			}
+			for i, id := range ids {
+				if got[d][i].TripID != id {
+					t.Errorf("%s: %s[%d] got %v, want %v", tt.name, d, i, got[d][i].TripID, id)
+				}
+			}
+		}
+	}
+}
+
+func TestArrivalsAge(t *testing.T) {
+	now := time.Now().UTC()
+	updated, at := now.Add(-30*time.Second), now.Add(time.Minute)
+	got := New().Arrivals(map[mta.Direction][]*mta.Arrival{
+		"N": {
+			{TripID: "n1", RouteID: "Q", Time: &at, Updated: &updated},
+			{TripID: "n2", RouteID: "Q", Time: &at},
+		},
+	}, nil)
+	if age := got["N"][0].Age; age == nil || *age < 30 || *age > 31 {
+		t.Errorf("Age got %v, want 30", age)
+	}
+	if age := got["N"][1].Age; age != nil {
+		t.Errorf("Age got %v, want nil without an update time", *age)
+	}
+}
@@ -0,0 +1,69 @@
+package protocol
+
+import "time"
+
+// StationDiff is how the arrivals of a station changed since they were
+// last sent: the arrivals added and changed, and the trip IDs of those
+// removed, by direction. Arrivals are identified by their trip; one whose
+// Age alone changed is not sent again.
+type StationDiff struct {
+	ID      string
+	Added   Arrivals            `json:",omitempty"`
+	Changed Arrivals            `json:",omitempty"`
+	Removed map[string][]string `json:",omitempty"`
+	Updated *time.Time          `json:",omitempty"`
+}
+
+// Diff returns how the arrivals of a station changed from prev to next, or
+// nil if they did not.
+func Diff(prev, next *Station) *StationDiff {
+	v := &StationDiff{ID: next.ID, Updated: next.Updated}
+	changed := false
+	add := func(m *Arrivals, d string, a *Arrival) {
+		if *m == nil {
+			*m = make(Arrivals)
+		}
+		(*m)[d] = append((*m)[d], a)
+		changed = true
+	}
+	for d, arrivals := range next.Arrivals {
+		before := make(map[string]*Arrival, len(prev.Arrivals[d]))
+		for _, a := range prev.Arrivals[d] {
+			before[a.TripID] = a
+		}
+		for _, a := range arrivals {
+			switch b, ok := before[a.TripID]; {
+			case !ok:
+				add(&v.Added, d, a)
+			case !sameArrival(a, b):
+				add(&v.Changed, d, a)
+			}
+		}
+	}
+	for d, arrivals := range prev.Arrivals {
+		after := make(map[string]bool, len(next.Arrivals[d]))
+		for _, a := range next.Arrivals[d] {
+			after[a.TripID] = true
+		}
+		for _, a := range arrivals {
+			if !after[a.TripID] {
+				if v.Removed == nil {
+					v.Removed = make(map[string][]string)
+				}
+				v.Removed[d] = append(v.Removed[d], a.TripID)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return v
+}
+
+func sameArrival(a, b *Arrival) bool {
+	if a.RouteID != b.RouteID || a.Stale != b.Stale || (a.Time == nil) != (b.Time == nil) {
+		return false
+	}
+	return a.Time == nil || a.Time.Equal(*b.Time)
+}
@@ -0,0 +1,55 @@
+package protocol
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(m int) *time.Time {
+		t := now.Add(time.Duration(m) * time.Minute)
+		return &t
+	}
+	age := 30
+	prev := &Station{ID: "L03", Arrivals: Arrivals{
+		"N": {{TripID: "a", RouteID: "Q", Time: at(1)}, {TripID: "b", RouteID: "Q", Time: at(5)}, {TripID: "c", RouteID: "Q", Time: at(9)}},
+		"S": {{TripID: "d", RouteID: "N", Time: at(2)}},
+	}}
+	next := &Station{ID: "L03", Updated: at(0), Arrivals: Arrivals{
+		// a has left, b is later, c is older and e is new.
+		"N": {{TripID: "b", RouteID: "Q", Time: at(6)}, {TripID: "c", RouteID: "Q", Time: at(9), Age: &age}, {TripID: "e", RouteID: "Q", Time: at(12)}},
+		"S": {{TripID: "d", RouteID: "N", Time: at(2), Stale: true}},
+	}}
+
+	v := Diff(prev, next)
+	if v == nil {
+		t.Fatal("Diff got nil")
+	}
+	ids := func(a Arrivals) map[string][]string {
+		result := make(map[string][]string)
+		for d, s := range a {
+			for _, u := range s {
+				result[d] = append(result[d], u.TripID)
+			}
+		}
+		return result
+	}
+	if got, want := ids(v.Added), map[string][]string{"N": {"e"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Added got %v, want %v", got, want)
+	}
+	if got, want := ids(v.Changed), map[string][]string{"N": {"b"}, "S": {"d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed got %v, want %v", got, want)
+	}
+	if got, want := v.Removed, map[string][]string{"N": {"a"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Removed got %v, want %v", got, want)
+	}
+	if v.ID != "L03" || v.Updated != next.Updated {
+		t.Errorf("got ID %v, Updated %v", v.ID, v.Updated)
+	}
+
+	if v := Diff(next, next); v != nil {
+		t.Errorf("Diff of the same arrivals got %+v, want nil", v)
+	}
+}
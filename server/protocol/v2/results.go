@@ -0,0 +1,73 @@
+package v2
+
+// StationsResult is the result of GetStations and GetClosestStations.
+type StationsResult struct {
+	Stations []*Station `json:"stations"`
+}
+
+// StationResult is the result of GetStation.
+type StationResult struct {
+	Station *Station `json:"station"`
+}
+
+// RoutesResult is the result of GetRoutes.
+type RoutesResult struct {
+	Routes []*Route `json:"routes"`
+}
+
+// ServiceResult is the result of GetSystemStatus.
+type ServiceResult struct {
+	Service *Service `json:"service"`
+}
+
+// HeadwaysResult is the result of GetHeadways.
+type HeadwaysResult struct {
+	Headways []*Headways `json:"headways"`
+}
+
+// AnomaliesResult is the result of GetAnomalies.
+type AnomaliesResult struct {
+	Anomalies []*Anomaly `json:"anomalies"`
+}
+
+// AccuracyResult is the result of GetPredictionAccuracy.
+type AccuracyResult struct {
+	Accuracy []*Accuracy `json:"accuracy"`
+}
+
+// PlannedWorkResult is the result of GetPlannedWork.
+type PlannedWorkResult struct {
+	PlannedWork []*PlannedWork `json:"plannedWork"`
+}
+
+// StatusHistoryResult is the result of GetStatusHistory.
+type StatusHistoryResult struct {
+	Changes []*StatusChange `json:"changes"`
+}
+
+// StatusStatsResult is the result of GetStatusStats.
+type StatusStatsResult struct {
+	Stats []*StatusStats `json:"stats"`
+}
+
+// CreateWebhookResult is the result of CreateWebhook.
+type CreateWebhookResult struct {
+	Webhook *Webhook `json:"webhook"`
+	Secret  string   `json:"secret"`
+}
+
+// WebhooksResult is the result of ListWebhooks.
+type WebhooksResult struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookResult is the result of DeleteWebhook.
+type WebhookResult struct {
+	Webhook *Webhook `json:"webhook"`
+}
+
+// PushSubscriptionResult is the result of SubscribePush.
+type PushSubscriptionResult struct {
+	Stations []string `json:"stations"`
+	Routes   []string `json:"routes"`
+}
@@ -0,0 +1,369 @@
+// Package v2 defines version 2 of the API contract. It differs from
+// version 1 in that:
+//
+//   - fields are named in lowerCamelCase;
+//   - the arrivals of a station are a single list ordered by time, each
+//     carrying its direction;
+//   - the service status lists lines rather than statuses.
+//
+// Values are translated from version 1, so changes to the mta package
+// only need to be absorbed once. The JSON encoding of these types is
+// pinned by the golden files in testdata.
+package v2
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jeffreylo/mtapi/server/protocol"
+)
+
+// Coordinates is the location of a station.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Arrival is the predicted arrival of a train.
+type Arrival struct {
+	TripID    string     `json:"tripId"`
+	RouteID   string     `json:"routeId"`
+	Direction string     `json:"direction"`
+	Time      *time.Time `json:"time,omitempty"`
+	Age       *int       `json:"age,omitempty"`
+	Stale     bool       `json:"stale,omitempty"`
+}
+
+// Station is a station and, when requested, its upcoming arrivals.
+type Station struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Coordinates *Coordinates `json:"coordinates"`
+	Arrivals    []*Arrival   `json:"arrivals,omitempty"`
+	Updated     *time.Time   `json:"updated,omitempty"`
+}
+
+// Route is a subway route.
+type Route struct {
+	ID          string `json:"id"`
+	ShortName   string `json:"shortName"`
+	LongName    string `json:"longName"`
+	Description string `json:"description"`
+	Color       string `json:"color,omitempty"`
+	TextColor   string `json:"textColor,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Service is the service status of the subway.
+type Service struct {
+	Updated *time.Time `json:"updated,omitempty"`
+	Lines   []*Line    `json:"lines"`
+}
+
+// Line is the service status of a line.
+type Line struct {
+	Name        string     `json:"name"`
+	OK          bool       `json:"ok"`
+	Category    string     `json:"category"`
+	Routes      []string   `json:"routes"`
+	Description string     `json:"description,omitempty"`
+	Posted      *time.Time `json:"posted,omitempty"`
+}
+
+// Headways describes the spacing of trains in seconds.
+type Headways struct {
+	RouteID   string `json:"routeId"`
+	StationID string `json:"stationId"`
+	Direction string `json:"direction"`
+	Predicted []int  `json:"predicted"`
+	Scheduled int    `json:"scheduled,omitempty"`
+	Gaps      []*Gap `json:"gaps"`
+}
+
+// Gap is a service gap between two consecutive trains.
+type Gap struct {
+	FromTripID string     `json:"fromTripId"`
+	ToTripID   string     `json:"toTripId"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+	Duration   int        `json:"duration"`
+}
+
+// Anomaly is an irregularity observed in the realtime feeds.
+type Anomaly struct {
+	Kind      string     `json:"kind"`
+	RouteID   string     `json:"routeId"`
+	TripIDs   []string   `json:"tripIds"`
+	StationID string     `json:"stationId,omitempty"`
+	Direction string     `json:"direction,omitempty"`
+	Time      *time.Time `json:"time,omitempty"`
+	Detected  *time.Time `json:"detected"`
+}
+
+// PlannedWork is work or a service change affecting routes over periods
+// of time.
+type PlannedWork struct {
+	ID          string    `json:"id"`
+	Category    string    `json:"category"`
+	Routes      []string  `json:"routes"`
+	Stations    []string  `json:"stations,omitempty"`
+	Header      string    `json:"header"`
+	Description string    `json:"description,omitempty"`
+	Periods     []*Period `json:"periods"`
+}
+
+// Period is a span of time; a missing start or end is unbounded.
+type Period struct {
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// StatusChange is a line entering a status category.
+type StatusChange struct {
+	Line        string    `json:"line"`
+	Routes      []string  `json:"routes"`
+	Category    string    `json:"category"`
+	Previous    string    `json:"previous,omitempty"`
+	Time        time.Time `json:"time"`
+	Description string    `json:"description,omitempty"`
+}
+
+// StatusStats is the minutes a line spent in each status category on a
+// day in New York.
+type StatusStats struct {
+	Line    string         `json:"line"`
+	Date    string         `json:"date"`
+	Minutes map[string]int `json:"minutes"`
+}
+
+// Webhook is a URL notified of the events matching a subscription.
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Kind      string    `json:"kind"`
+	RouteID   string    `json:"routeId,omitempty"`
+	StationID string    `json:"stationId,omitempty"`
+	Direction string    `json:"direction,omitempty"`
+	MinDelay  int       `json:"minDelay,omitempty"`
+	Created   time.Time `json:"created"`
+}
+
+// Accuracy is the distribution of prediction error in seconds for a route
+// and lookahead bucket.
+type Accuracy struct {
+	RouteID string  `json:"routeId"`
+	Bucket  string  `json:"bucket"`
+	Count   int     `json:"count"`
+	Mean    int     `json:"mean"`
+	P10     int     `json:"p10"`
+	P50     int     `json:"p50"`
+	P90     int     `json:"p90"`
+	OnTime  float64 `json:"onTime"`
+}
+
+// NewStation translates a station.
+func NewStation(v *protocol.Station) *Station {
+	station := &Station{
+		ID:      v.ID,
+		Name:    v.Name,
+		Updated: v.Updated,
+	}
+	if v.Coordinates != nil {
+		station.Coordinates = &Coordinates{Lat: v.Coordinates.Lat, Lon: v.Coordinates.Lon}
+	}
+	for d, arrivals := range v.Arrivals {
+		for _, a := range arrivals {
+			station.Arrivals = append(station.Arrivals, &Arrival{
+				TripID:    a.TripID,
+				RouteID:   a.RouteID,
+				Direction: d,
+				Time:      a.Time,
+				Age:       a.Age,
+				Stale:     a.Stale,
+			})
+		}
+	}
+	sort.SliceStable(station.Arrivals, func(i, j int) bool {
+		a, b := station.Arrivals[i], station.Arrivals[j]
+		switch {
+		case a.Time == nil || b.Time == nil:
+			return b.Time == nil && a.Time != nil
+		case !a.Time.Equal(*b.Time):
+			return a.Time.Before(*b.Time)
+		}
+		return a.Direction < b.Direction
+	})
+	return station
+}
+
+// NewStations translates stations.
+func NewStations(v []*protocol.Station) []*Station {
+	result := make([]*Station, 0, len(v))
+	for _, s := range v {
+		result = append(result, NewStation(s))
+	}
+	return result
+}
+
+// NewRoutes translates routes.
+func NewRoutes(v []*protocol.Route) []*Route {
+	result := make([]*Route, 0, len(v))
+	for _, r := range v {
+		result = append(result, &Route{
+			ID:          r.ID,
+			ShortName:   r.ShortName,
+			LongName:    r.LongName,
+			Description: r.Description,
+			Color:       r.Color,
+			TextColor:   r.TextColor,
+			URL:         r.URL,
+		})
+	}
+	return result
+}
+
+// NewService translates the service status.
+func NewService(v *protocol.Service) *Service {
+	lines := make([]*Line, 0, len(v.Status))
+	for _, s := range v.Status {
+		lines = append(lines, &Line{
+			Name:        s.Line,
+			OK:          s.OK,
+			Category:    s.Category,
+			Routes:      s.Routes,
+			Description: s.Description,
+			Posted:      s.Posted,
+		})
+	}
+	return &Service{Updated: v.Updated, Lines: lines}
+}
+
+// NewHeadways translates headways.
+func NewHeadways(v []*protocol.Headways) []*Headways {
+	result := make([]*Headways, 0, len(v))
+	for _, h := range v {
+		gaps := make([]*Gap, 0, len(h.Gaps))
+		for _, g := range h.Gaps {
+			gaps = append(gaps, &Gap{
+				FromTripID: g.FromTripID,
+				ToTripID:   g.ToTripID,
+				From:       g.From,
+				To:         g.To,
+				Duration:   g.Duration,
+			})
+		}
+		result = append(result, &Headways{
+			RouteID:   h.RouteID,
+			StationID: h.StationID,
+			Direction: h.Direction,
+			Predicted: h.Predicted,
+			Scheduled: h.Scheduled,
+			Gaps:      gaps,
+		})
+	}
+	return result
+}
+
+// NewAnomalies translates anomalies.
+func NewAnomalies(v []*protocol.Anomaly) []*Anomaly {
+	result := make([]*Anomaly, 0, len(v))
+	for _, a := range v {
+		result = append(result, &Anomaly{
+			Kind:      a.Kind,
+			RouteID:   a.RouteID,
+			TripIDs:   a.TripIDs,
+			StationID: a.StationID,
+			Direction: a.Direction,
+			Time:      a.Time,
+			Detected:  a.Detected,
+		})
+	}
+	return result
+}
+
+// NewPlannedWork translates planned work.
+func NewPlannedWork(v []*protocol.PlannedWork) []*PlannedWork {
+	result := make([]*PlannedWork, 0, len(v))
+	for _, w := range v {
+		periods := make([]*Period, 0, len(w.Periods))
+		for _, p := range w.Periods {
+			periods = append(periods, &Period{Start: p.Start, End: p.End})
+		}
+		result = append(result, &PlannedWork{
+			ID:          w.ID,
+			Category:    w.Category,
+			Routes:      w.Routes,
+			Stations:    w.Stations,
+			Header:      w.Header,
+			Description: w.Description,
+			Periods:     periods,
+		})
+	}
+	return result
+}
+
+// NewStatusChanges translates changes of service status.
+func NewStatusChanges(v []*protocol.StatusChange) []*StatusChange {
+	result := make([]*StatusChange, 0, len(v))
+	for _, c := range v {
+		result = append(result, &StatusChange{
+			Line:        c.Line,
+			Routes:      c.Routes,
+			Category:    c.Category,
+			Previous:    c.Previous,
+			Time:        c.Time,
+			Description: c.Description,
+		})
+	}
+	return result
+}
+
+// NewStatusStats translates service status statistics.
+func NewStatusStats(v []*protocol.StatusStats) []*StatusStats {
+	result := make([]*StatusStats, 0, len(v))
+	for _, s := range v {
+		result = append(result, &StatusStats{Line: s.Line, Date: s.Date, Minutes: s.Minutes})
+	}
+	return result
+}
+
+// NewWebhook translates a webhook.
+func NewWebhook(v *protocol.Webhook) *Webhook {
+	return &Webhook{
+		ID:        v.ID,
+		URL:       v.URL,
+		Kind:      v.Kind,
+		RouteID:   v.RouteID,
+		StationID: v.StationID,
+		Direction: v.Direction,
+		MinDelay:  v.MinDelay,
+		Created:   v.Created,
+	}
+}
+
+// NewWebhooks translates webhooks.
+func NewWebhooks(v []*protocol.Webhook) []*Webhook {
+	result := make([]*Webhook, 0, len(v))
+	for _, w := range v {
+		result = append(result, NewWebhook(w))
+	}
+	return result
+}
+
+// NewAccuracy translates prediction accuracy.
+func NewAccuracy(v []*protocol.Accuracy) []*Accuracy {
+	result := make([]*Accuracy, 0, len(v))
+	for _, a := range v {
+		result = append(result, &Accuracy{
+			RouteID: a.RouteID,
+			Bucket:  a.Bucket,
+			Count:   a.Count,
+			Mean:    a.Mean,
+			P10:     a.P10,
+			P50:     a.P50,
+			P90:     a.P90,
+			OnTime:  a.OnTime,
+		})
+	}
+	return result
+}
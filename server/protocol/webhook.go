@@ -0,0 +1,41 @@
+package protocol
+
+import "time"
+
+// Webhook is a URL notified of the events matching a subscription.
+type Webhook struct {
+	ID   string
+	URL  string
+	Kind string
+	// RouteID is the public route ID of the route whose line or trains are
+	// watched; status webhooks without one are notified of every line.
+	RouteID   string `json:",omitempty"`
+	StationID string `json:",omitempty"`
+	Direction string `json:",omitempty"`
+	// MinDelay is the delay in seconds beyond which the next train is
+	// notified.
+	MinDelay int `json:",omitempty"`
+	Created  time.Time
+}
+
+// WebhookEvent is the body posted to a webhook.
+type WebhookEvent struct {
+	ID      string
+	Webhook string
+	Kind    string
+	Time    time.Time
+	Status  *StatusChange `json:",omitempty"`
+	Delay   *Delay        `json:",omitempty"`
+}
+
+// Delay is the next train of a route at a station predicted to arrive
+// later than first predicted.
+type Delay struct {
+	RouteID   string
+	TripID    string
+	StationID string
+	Direction string
+	Time      time.Time
+	// Delay is in seconds.
+	Delay int
+}
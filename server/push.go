@@ -0,0 +1,361 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/pkg/strings2"
+	"github.com/jeffreylo/mtapi/pkg/webpush"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+const (
+	// maxPushSubscriptions is the number of browsers that may subscribe,
+	// and maxCallerPushSubscriptions the number a caller may subscribe:
+	// an API key, or an address, which may be shared by the browsers of
+	// an office.
+	maxPushSubscriptions       = 10000
+	maxCallerPushSubscriptions = 50
+	// maxPushItems is the number of stations, and of routes, a browser may
+	// subscribe to.
+	maxPushItems = 10
+	// arrivalPushTTL is how long a push service keeps a message about the
+	// next train, which is soon out of date, for an offline browser.
+	arrivalPushTTL = time.Minute
+	statusPushTTL  = time.Hour
+	// vapidExpiry is how long the VAPID token of a push is valid.
+	vapidExpiry = 12 * time.Hour
+	// maxPushBody bounds the description of a status in a notification.
+	maxPushBody = 300
+)
+
+// directionNames are how the client names the directions.
+var directionNames = map[mta.Direction]string{
+	"N": "Uptown / Manhattan",
+	"S": "Downtown / Brooklyn",
+}
+
+// nextTrain is the next train last pushed.
+type nextTrain struct {
+	tripID string
+	time   time.Time
+}
+
+// pushSubscription is a browser subscribed to the next trains at stations
+// and the service status of routes.
+type pushSubscription struct {
+	// caller is who subscribed the browser, as returned by caller.
+	caller   string
+	sub      webpush.Subscription
+	stations []string
+	routes   []string
+	// next is the next train last pushed by station and direction.
+	next map[string]nextTrain
+}
+
+// pushes holds the push subscriptions, pushing to them after each refresh
+// the next trains that changed and the changes of service status. Like
+// webhooks, subscriptions are kept in memory.
+type pushes struct {
+	client    *mta.Client
+	vapid     *webpush.VAPID
+	deliverer *deliverer
+
+	mtx  sync.Mutex
+	subs map[string]*pushSubscription
+}
+
+func newPushes(client *mta.Client, vapid *webpush.VAPID) *pushes {
+	return &pushes{
+		client:    client,
+		vapid:     vapid,
+		deliverer: newDeliverer("push", pushDeliveries),
+		subs:      make(map[string]*pushSubscription),
+	}
+}
+
+// publicKey returns the application server key browsers subscribe with, or
+// an empty string if Web Push is not enabled.
+func (p *pushes) publicKey() string {
+	if p.vapid == nil {
+		return ""
+	}
+	return webpush.EncodePublicKey(&p.vapid.Key.PublicKey)
+}
+
+// run matches the subscriptions against each refresh and delivers their
+// messages until quit is closed.
+func (p *pushes) run(quit <-chan struct{}) {
+	go p.deliverer.run(quit)
+
+	sub := p.client.SubscribeStatus()
+	defer p.client.Unsubscribe(sub)
+	for {
+		select {
+		case <-quit:
+			return
+		case <-sub.C:
+			p.match(sub.Updated(), sub.StatusChanges(), time.Now().UTC())
+		}
+	}
+}
+
+// match queues the messages of the subscriptions to the stations refreshed
+// and the routes whose status changed.
+func (p *pushes) match(updated []mta.StationID, changes []*mta.StatusChange, now time.Time) {
+	stations := make(map[string]*mta.Station, len(updated))
+	for _, id := range updated {
+		if station, err := p.client.GetStation(id); err == nil {
+			stations[string(id)] = station
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, s := range p.subs {
+		for _, id := range s.stations {
+			if station, ok := stations[id]; ok {
+				for _, n := range s.nextTrains(station, now) {
+					p.push(s, n, arrivalPushTTL, now)
+				}
+			}
+		}
+		for _, v := range changes {
+			for _, routeID := range s.routes {
+				if servesRoute(v.Routes, routeID) {
+					p.push(s, statusNotification(v), statusPushTTL, now)
+					break
+				}
+			}
+		}
+	}
+}
+
+// nextTrains returns notifications of the next trains at a station in each
+// direction that changed since last pushed: a different train is next, or
+// the next train is predicted a minute or more earlier or later.
+func (s *pushSubscription) nextTrains(station *mta.Station, now time.Time) []*protocol.Notification {
+	var result []*protocol.Notification
+	for _, d := range []mta.Direction{"N", "S"} {
+		var next *mta.Arrival
+		for _, a := range station.Arrivals[d] {
+			if a.Time != nil && a.Time.After(now) && !a.Stale(now) {
+				next = a
+				break
+			}
+		}
+		key := string(station.ID) + string(d)
+		if next == nil {
+			delete(s.next, key)
+			continue
+		}
+		last, ok := s.next[key]
+		if ok && last.tripID == next.TripID && absDuration(next.Time.Sub(last.time)) < time.Minute {
+			continue
+		}
+		s.next[key] = nextTrain{next.TripID, *next.Time}
+		minutes := int(next.Time.Sub(now) / time.Minute)
+		result = append(result, &protocol.Notification{
+			Title: station.Name,
+			Body:  fmt.Sprintf("%s train to %s in %d min", mta.PublicRouteID(next.RouteID), directionNames[d], minutes),
+			Tag:   key,
+		})
+	}
+	return result
+}
+
+// statusNotification returns the notification of a change of service
+// status.
+func statusNotification(v *mta.StatusChange) *protocol.Notification {
+	category := strings.Replace(string(v.Category), "_", " ", -1)
+	body := v.Description
+	if r := []rune(body); len(r) > maxPushBody {
+		body = strings.TrimSpace(string(r[:maxPushBody])) + "…"
+	}
+	return &protocol.Notification{
+		Title: fmt.Sprintf("%s: %s", v.Line, category),
+		Body:  body,
+		Tag:   "status" + v.Line,
+	}
+}
+
+// push queues a notification to a subscription. Subscriptions the push
+// service reports gone are removed. The caller must hold p.mtx.
+func (p *pushes) push(s *pushSubscription, n *protocol.Notification, ttl time.Duration, now time.Time) {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	body, err := webpush.Encrypt(&s.sub, payload)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	auth, err := p.vapid.Authorization(s.sub.Endpoint, now.Add(vapidExpiry))
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	header := make(http.Header)
+	header.Set("Authorization", auth)
+	header.Set("Content-Type", "application/octet-stream")
+	header.Set("Content-Encoding", "aes128gcm")
+	header.Set("TTL", strconv.Itoa(int(ttl/time.Second)))
+	// A message replaces any the push service holds on the same topic.
+	header.Set("Topic", n.Tag)
+
+	endpoint := s.sub.Endpoint
+	p.deliverer.enqueue(&delivery{
+		id:     newID(8),
+		url:    endpoint,
+		header: header,
+		body:   body,
+		gone:   func() { p.remove(endpoint, s) },
+	})
+}
+
+// remove unsubscribes a browser unless it subscribed again since.
+func (p *pushes) remove(endpoint string, s *pushSubscription) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.subs[endpoint] == s {
+		delete(p.subs, endpoint)
+	}
+}
+
+// subscribe replaces the stations and routes a browser is subscribed to by
+// a caller, unsubscribing it if there are none.
+func (p *pushes) subscribe(caller string, sub webpush.Subscription, stations, routes []string) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if len(stations) == 0 && len(routes) == 0 {
+		delete(p.subs, sub.Endpoint)
+		return nil
+	}
+	if _, ok := p.subs[sub.Endpoint]; !ok {
+		if len(p.subs) >= maxPushSubscriptions {
+			return fmt.Errorf("too many subscriptions")
+		}
+		var n int
+		for _, v := range p.subs {
+			if v.caller == caller {
+				n++
+			}
+		}
+		if n >= maxCallerPushSubscriptions {
+			return fmt.Errorf("at most %d browsers may be subscribed from one address or API key", maxCallerPushSubscriptions)
+		}
+	}
+	p.subs[sub.Endpoint] = &pushSubscription{
+		caller:   caller,
+		sub:      sub,
+		stations: stations,
+		routes:   routes,
+		next:     make(map[string]nextTrain),
+	}
+	return nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// SubscribePushHandler subscribes a browser to Web Push notifications.
+type SubscribePushHandler struct {
+	client *mta.Client
+	pushes *pushes
+}
+
+// SubscribePushParams defines the parameters of the SubscribePush RPC.
+type SubscribePushParams struct {
+	// Subscription is the browser's PushSubscription as JSON.
+	Subscription webpush.Subscription
+	// Stations are those whose next trains are pushed, and Routes those
+	// whose changes of service status are, kept as public route IDs; a
+	// browser subscribed to neither is unsubscribed.
+	Stations []string
+	Routes   []string
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h SubscribePushHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	if h.pushes.vapid == nil {
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidRequest,
+			Message: "Web Push is not enabled",
+		}
+	}
+	var p SubscribePushParams
+	if err := jsonrpc.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	p.Stations = strings2.Unique(p.Stations)
+	for i, v := range p.Routes {
+		p.Routes[i] = mta.PublicRouteID(v)
+	}
+	p.Routes = strings2.Unique(p.Routes)
+	if err := h.validate(&p); err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.ErrorCodeInvalidParams, Message: err.Error()}
+	}
+	if err := h.pushes.subscribe(caller(c), p.Subscription, p.Stations, p.Routes); err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.ErrorCodeInvalidRequest, Message: err.Error()}
+	}
+	return SubscribePushResult{Stations: p.Stations, Routes: p.Routes}, nil
+}
+
+func (h SubscribePushHandler) validate(p *SubscribePushParams) error {
+	// Push services are served over HTTPS, and an endpoint must not reach
+	// the server's own network.
+	if err := h.pushes.deliverer.checkURL(p.Subscription.Endpoint, "https"); err != nil {
+		return err
+	}
+	if err := p.Subscription.Validate(); err != nil {
+		return err
+	}
+	if len(p.Stations) > maxPushItems || len(p.Routes) > maxPushItems {
+		return fmt.Errorf("at most %d stations and %d routes may be subscribed to", maxPushItems, maxPushItems)
+	}
+	for _, id := range p.Stations {
+		if _, err := h.client.GetStation(mta.StationID(id)); err != nil {
+			return fmt.Errorf("invalid station %q", id)
+		}
+	}
+	for _, id := range p.Routes {
+		if !knownRoute(h.client, id) {
+			return fmt.Errorf("invalid route %q", id)
+		}
+	}
+	return nil
+}
+
+// knownRoute reports whether a public route ID is that of one of the
+// client's routes.
+func knownRoute(client *mta.Client, routeID string) bool {
+	for _, r := range client.GetRoutes() {
+		if mta.PublicRouteID(r.ID) == routeID {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscribePushResult describes the response of the SubscribePush RPC:
+// what the browser is subscribed to.
+type SubscribePushResult struct {
+	Stations []string
+	Routes   []string
+}
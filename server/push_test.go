@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/pkg/webpush"
+	"github.com/jeffreylo/mtapi/pkg/webpush/pushtest"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+// waitMessages waits for the push service to deliver n messages to an
+// endpoint.
+func waitMessages(t *testing.T, srv *pushtest.Server, endpoint string, n int) []*pushtest.Message {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		messages := srv.Messages(endpoint)
+		if len(messages) >= n || time.Now().After(deadline) {
+			if len(messages) != n {
+				t.Fatalf("got %v messages, want %v", len(messages), n)
+			}
+			return messages
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPush(t *testing.T) {
+	srv := pushtest.NewServer()
+	defer srv.Close()
+	key, err := webpush.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushes := newPushes(client(t), &webpush.VAPID{Key: key, Subject: "mailto:ops@example.com"})
+	// The push service is on the loopback interface, with a certificate
+	// its client trusts.
+	pushes.deliverer.allowed = anyIP
+	pushes.deliverer.client.Transport.(*http.Transport).TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig
+	pushes.deliverer.retry = time.Millisecond
+	quit := make(chan struct{})
+	defer close(quit)
+	pushes.deliverer.run(quit)
+
+	sub, err := srv.Subscribe(pushes.publicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(sub)
+	h := SubscribePushHandler{client: pushes.client, pushes: pushes}
+	var tests = []struct {
+		params string
+		code   jsonrpc.ErrorCode
+	}{
+		{`{"Subscription":{"endpoint":"` + sub.Endpoint + `","keys":{"p256dh":"AAAA","auth":"` + sub.Keys.Auth + `"}},"Routes":["L"]}`, jsonrpc.ErrorCodeInvalidParams},
+		{`{"Subscription":` + string(b) + `,"Stations":["XXX"]}`, jsonrpc.ErrorCodeInvalidParams},
+		{`{"Subscription":` + string(b) + `,"Routes":["XX"]}`, jsonrpc.ErrorCodeInvalidParams},
+		{`{"Subscription":` + string(b) + `,"Stations":["L03"],"Routes":["L","L","6X"]}`, 0},
+	}
+	for _, tt := range tests {
+		_, err := h.ServeJSONRPC(context.Background(), rawParams(tt.params))
+		if got := jsonrpc.ErrorCode(0); err != nil {
+			got = err.Code
+			if got != tt.code {
+				t.Errorf("%s: got %v, want code %v", tt.params, err, tt.code)
+			}
+		} else if tt.code != 0 {
+			t.Errorf("%s: got no error, want code %v", tt.params, tt.code)
+		}
+	}
+	// Routes are kept as public route IDs.
+	if got, want := pushes.subs[sub.Endpoint].routes, []string{"L", "6"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("routes got %v, want %v", got, want)
+	}
+
+	now := time.Now().UTC()
+	changes := []*mta.StatusChange{
+		{Line: "NQR", Routes: []string{"N", "Q", "R"}, Category: mta.StatusDelays, Time: now},
+		{Line: "L", Routes: []string{"L"}, Category: mta.StatusServiceChange, Time: now, Description: "No [L] trains between 8 Av and Lorimer St."},
+	}
+	pushes.match(nil, changes, now)
+	messages := waitMessages(t, srv, sub.Endpoint, 1)
+	var n protocol.Notification
+	if err := json.Unmarshal(messages[0].Payload, &n); err != nil {
+		t.Fatal(err)
+	}
+	want := protocol.Notification{Title: "L: service change", Body: changes[1].Description, Tag: "statusL"}
+	if n != want {
+		t.Errorf("got %+v, want %+v", n, want)
+	}
+	if m := messages[0]; m.TTL != 3600 || m.Topic != "statusL" || m.Subject != "mailto:ops@example.com" {
+		t.Errorf("got TTL %v, Topic %v, Subject %v", m.TTL, m.Topic, m.Subject)
+	}
+
+	// Subscribers of the 6 are sent the status of the 6X.
+	pushes.match(nil, []*mta.StatusChange{{Line: "456", Routes: []string{"4", "6X"}, Category: mta.StatusDelays, Time: now}}, now)
+	messages = waitMessages(t, srv, sub.Endpoint, 2)
+	if m := messages[1]; m.Topic != "status456" {
+		t.Errorf("got Topic %v, want status456", m.Topic)
+	}
+
+	// A subscription the browser has given up is removed.
+	srv.Unsubscribe(sub.Endpoint)
+	pushes.match(nil, changes, now)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pushes.mtx.Lock()
+		_, ok := pushes.subs[sub.Endpoint]
+		pushes.mtx.Unlock()
+		if !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscription was not removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSubscribePushLimits(t *testing.T) {
+	key, err := webpush.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushes := newPushes(client(t), &webpush.VAPID{Key: key, Subject: "mailto:ops@example.com"})
+	h := SubscribePushHandler{client: pushes.client, pushes: pushes}
+	for _, endpoint := range []string{
+		"http://push.example.com/send/a",
+		"https://127.0.0.1/send/a",
+		"https://[fe80::1]/send/a",
+		"https://localhost/send/a",
+	} {
+		params := `{"Subscription":{"endpoint":"` + endpoint + `","keys":{"p256dh":"AAAA","auth":"AAAA"}},"Routes":["L"]}`
+		if _, err := h.ServeJSONRPC(context.Background(), rawParams(params)); err == nil || err.Code != jsonrpc.ErrorCodeInvalidParams {
+			t.Errorf("%s: got %v, want code %v", endpoint, err, jsonrpc.ErrorCodeInvalidParams)
+		}
+	}
+
+	subscribe := func(caller string, i int) error {
+		sub := webpush.Subscription{Endpoint: fmt.Sprintf("https://push.example.com/send/%s/%d", caller, i)}
+		return pushes.subscribe(caller, sub, nil, []string{"L"})
+	}
+	for i := 0; i < maxCallerPushSubscriptions; i++ {
+		if err := subscribe("anonymous 192.0.2.1", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := subscribe("anonymous 192.0.2.1", maxCallerPushSubscriptions); err == nil {
+		t.Error("subscription beyond the caller's limit: got no error")
+	}
+	// A browser subscribed already may change its subscription.
+	if err := subscribe("anonymous 192.0.2.1", 0); err != nil {
+		t.Errorf("resubscribe: got %v", err)
+	}
+	if err := subscribe("anonymous 192.0.2.2", 0); err != nil {
+		t.Errorf("another caller: got %v", err)
+	}
+}
+
+func TestPushNextTrains(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(seconds int) *time.Time {
+		v := now.Add(time.Duration(seconds) * time.Second)
+		return &v
+	}
+	s := &pushSubscription{next: make(map[string]nextTrain)}
+	station := func(arrivals ...*mta.Arrival) *mta.Station {
+		return &mta.Station{ID: "L03", Name: "Union Sq - 14 St", Arrivals: map[mta.Direction][]*mta.Arrival{"N": arrivals}}
+	}
+	var tests = []struct {
+		station *mta.Station
+		want    []string
+	}{
+		{station(&mta.Arrival{TripID: "a", RouteID: "L", Time: at(-30)}, &mta.Arrival{TripID: "b", RouteID: "L", Time: at(150)}), []string{"L train to Uptown / Manhattan in 2 min"}},
+		// b is predicted under a minute later.
+		{station(&mta.Arrival{TripID: "b", RouteID: "L", Time: at(200)}), nil},
+		{station(&mta.Arrival{TripID: "b", RouteID: "L", Time: at(240)}), []string{"L train to Uptown / Manhattan in 4 min"}},
+		{station(&mta.Arrival{TripID: "c", RouteID: "L", Time: at(240)}), []string{"L train to Uptown / Manhattan in 4 min"}},
+		// Trains are named by public route ID.
+		{station(&mta.Arrival{TripID: "d", RouteID: "GS", Time: at(420)}), []string{"S train to Uptown / Manhattan in 7 min"}},
+		{station(), nil},
+	}
+	for i, tt := range tests {
+		var got []string
+		for _, n := range s.nextTrains(tt.station, now) {
+			if n.Title != "Union Sq - 14 St" || n.Tag != "L03N" {
+				t.Errorf("%d: got %+v", i, n)
+			}
+			got = append(got, n.Body)
+		}
+		if len(got) != len(tt.want) || len(got) > 0 && got[0] != tt.want[0] {
+			t.Errorf("%d: got %v, want %v", i, got, tt.want)
+		}
+	}
+	if len(s.next) != 0 {
+		t.Errorf("next got %v, want none", s.next)
+	}
+}
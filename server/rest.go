@@ -0,0 +1,245 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/julienschmidt/httprouter"
+	"github.com/osamingo/jsonrpc"
+)
+
+const (
+	// staticMaxAge is how long responses derived from static GTFS may be
+	// cached.
+	staticMaxAge = time.Hour
+	// realtimeMaxAge is how long responses derived from the feeds may be
+	// cached; the feeds refresh every few seconds.
+	realtimeMaxAge = 5 * time.Second
+	// statusMaxAge is how long the service status may be cached.
+	statusMaxAge = time.Minute
+)
+
+// restAPI serves the read-only REST API under a prefix, e.g., /api/v1,
+// from the same handlers as the JSON-RPC methods:
+//
+//	GET /api/v1/stations
+//	GET /api/v1/stations/:id?routes=Q,N&directions=S&limit=3&within=600
+//	GET /api/v1/closest?lat=40.73&lon=-73.99&n=3
+//	GET /api/v1/status
+//	GET /api/v1/routes
+//
+// Responses carry an ETag and Last-Modified derived from the last feed
+// refresh, or from the start of the process for static data. The ETags of
+// arrivals also cover the body, as their ages change between refreshes.
+type restAPI struct {
+	prefix string
+	// translate, if set, translates results to another protocol version.
+	translate func(interface{}) interface{}
+
+	client   *mta.Client
+	started  time.Time
+	stations GetStationsHandler
+	station  GetStationHandler
+	closest  GetClosestHandler
+	status   GetSystemStatusHandler
+	routes   GetRoutesHandler
+}
+
+// register adds the routes of the API to m, wrapped by the middleware,
+// which is given the route.
+func (a *restAPI) register(m *httprouter.Router, wrap func(string, http.Handler) http.Handler) {
+	routes := map[string]httprouter.Handle{
+		"/stations":     a.getStations,
+		"/stations/:id": a.getStation,
+		"/closest":      a.getClosest,
+		"/status":       a.getStatus,
+		"/routes":       a.getRoutes,
+	}
+	for path, h := range routes {
+		m.GET(a.prefix+path, handle(h, wrap, "GET "+a.prefix+path))
+	}
+}
+
+// handle adapts a handler taking route parameters to the middleware.
+func handle(h httprouter.Handle, wrap func(string, http.Handler) http.Handler, route string) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		wrap(route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h(w, r, ps)
+		})).ServeHTTP(w, r)
+	}
+}
+
+// realtime returns the version of responses derived from the feeds.
+func (a *restAPI) realtime() time.Time {
+	if updated := a.client.Updated(); updated != nil {
+		return *updated
+	}
+	return a.started
+}
+
+func (a *restAPI) getStations(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeCached(w, r, a.started, staticMaxAge, a.encode(a.stations.result()))
+}
+
+func (a *restAPI) getStation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	p, err := arrivalParams(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	result, err := a.station.result(&GetStationParams{ID: ps.ByName("id"), ArrivalParams: p})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeArrivals(w, r, a.realtime(), realtimeMaxAge, a.encode(result))
+}
+
+func (a *restAPI) getClosest(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	q := r.URL.Query()
+	p, err := arrivalParams(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	lat, lon, err := coordinates(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	n, err := intParam(q, "n")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	result, err := a.closest.result(&GetClosestParams{Lat: lat, Lon: lon, NumStations: n, ArrivalParams: p})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeArrivals(w, r, a.realtime(), realtimeMaxAge, a.encode(result))
+}
+
+func (a *restAPI) getStatus(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	result, err := a.status.result()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	version := a.started
+	if result.Service.Updated != nil {
+		version = *result.Service.Updated
+	}
+	writeCached(w, r, version, statusMaxAge, a.encode(result))
+}
+
+func (a *restAPI) getRoutes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeCached(w, r, a.started, staticMaxAge, a.encode(a.routes.result()))
+}
+
+func (a *restAPI) encode(v interface{}) interface{} {
+	if a.translate != nil {
+		return a.translate(v)
+	}
+	return v
+}
+
+// writeCached writes v as JSON, or Not Modified if the client's copy of
+// the given version is current.
+func writeCached(w http.ResponseWriter, r *http.Request, version time.Time, maxAge time.Duration, v interface{}) {
+	if notModified(w, r, fmt.Sprintf(`W/"%x"`, version.UnixNano()), version, maxAge) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeArrivals writes v as JSON like writeCached. Arrivals carry their age,
+// which changes between versions, so the ETag covers the body as well.
+func writeArrivals(w http.ResponseWriter, r *http.Request, version time.Time, maxAge time.Duration, v interface{}) {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(v)
+	h := fnv.New64a()
+	h.Write(buf.Bytes())
+	if notModified(w, r, fmt.Sprintf(`W/"%x-%x"`, version.UnixNano(), h.Sum64()), version, maxAge) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes())
+}
+
+// notModified sets the caching headers of the given ETag and version and
+// writes Not Modified if the client's copy is current.
+func notModified(w http.ResponseWriter, r *http.Request, etag string, version time.Time, maxAge time.Duration) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", version.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge/time.Second)))
+	if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+func invalidParam(name string) error {
+	return &jsonrpc.Error{
+		Code:    jsonrpc.ErrorCodeInvalidParams,
+		Message: fmt.Sprintf("invalid %s", name),
+	}
+}
+
+func intParam(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, invalidParam(name)
+	}
+	return n, nil
+}
+
+func listParam(q url.Values, name string) []string {
+	if v := q.Get(name); v != "" {
+		return strings.Split(v, ",")
+	}
+	return nil
+}
+
+func coordinates(q url.Values) (lat, lon float64, err error) {
+	if lat, err = strconv.ParseFloat(q.Get("lat"), 64); err != nil {
+		return 0, 0, invalidParam("lat")
+	}
+	if lon, err = strconv.ParseFloat(q.Get("lon"), 64); err != nil {
+		return 0, 0, invalidParam("lon")
+	}
+	return lat, lon, nil
+}
+
+// arrivalParams reads the arrival filters from a query string.
+func arrivalParams(q url.Values) (ArrivalParams, error) {
+	var (
+		p   ArrivalParams
+		err error
+	)
+	p.Routes = listParam(q, "routes")
+	for _, d := range listParam(q, "directions") {
+		p.Directions = append(p.Directions, mta.Direction(d))
+	}
+	if p.Limit, err = intParam(q, "limit"); err != nil {
+		return p, err
+	}
+	if p.Within, err = intParam(q, "within"); err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/julienschmidt/httprouter"
+)
+
+func restServer(t *testing.T) http.Handler {
+	c := client(t)
+	rest := &restAPI{
+		prefix:   "/api/v1",
+		client:   c,
+		started:  time.Now().UTC(),
+		stations: GetStationsHandler{client: c, p: protocol.New()},
+		station:  GetStationHandler{client: c, p: protocol.New()},
+		closest:  GetClosestHandler{client: c, p: protocol.New()},
+		status:   GetSystemStatusHandler{client: c, p: protocol.New()},
+		routes:   GetRoutesHandler{client: c, p: protocol.New()},
+	}
+	m := httprouter.New()
+	rest.register(m, func(_ string, h http.Handler) http.Handler { return h })
+	return m
+}
+
+func TestREST(t *testing.T) {
+	var tests = []struct {
+		path   string
+		status int
+		count  func(body []byte) int
+		want   int
+	}{
+		{"/api/v1/stations", 200, func(b []byte) int {
+			var v GetStationsResult
+			json.Unmarshal(b, &v)
+			return len(v.Stations)
+		}, 414},
+		{"/api/v1/routes", 200, func(b []byte) int {
+			var v GetRoutesResult
+			json.Unmarshal(b, &v)
+			return len(v.Routes)
+		}, 30},
+		{"/api/v1/closest?lat=40.7347908&lon=-73.9907299&n=2&routes=Q", 200, func(b []byte) int {
+			var v struct{ Stations []*protocol.Station }
+			json.Unmarshal(b, &v)
+			return len(v.Stations)
+		}, 2},
+		{"/api/v1/stations/L03?directions=S&limit=3", 200, nil, 0},
+		{"/api/v1/stations/foo", 404, nil, 0},
+		{"/api/v1/stations/L03?directions=E", 400, nil, 0},
+		{"/api/v1/closest?lat=40.7347908", 400, nil, 0},
+	}
+
+	h := restServer(t)
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status got %v, want %v", tt.path, w.Code, tt.status)
+			continue
+		}
+		if tt.count != nil {
+			if got := tt.count(w.Body.Bytes()); got != tt.want {
+				t.Errorf("%s: got %v, want %v", tt.path, got, tt.want)
+			}
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		etag := w.Header().Get("ETag")
+		if etag == "" || w.Header().Get("Cache-Control") == "" {
+			t.Errorf("%s: missing cache headers", tt.path)
+			continue
+		}
+
+		r := httptest.NewRequest("GET", tt.path, nil)
+		r.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("%s: conditional status got %v, want %v", tt.path, w.Code, http.StatusNotModified)
+		}
+	}
+}
+
+func TestWriteArrivals(t *testing.T) {
+	version := time.Now()
+	write := func(v interface{}, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/api/v1/stations/L03", nil)
+		r.Header.Set("If-None-Match", etag)
+		w := httptest.NewRecorder()
+		writeArrivals(w, r, version, realtimeMaxAge, v)
+		return w
+	}
+	etag := write(map[string]int{"Age": 10}, "").Header().Get("ETag")
+	if w := write(map[string]int{"Age": 10}, etag); w.Code != http.StatusNotModified {
+		t.Errorf("unchanged status got %v, want %v", w.Code, http.StatusNotModified)
+	}
+	// The age of an arrival changes without a new version.
+	if w := write(map[string]int{"Age": 11}, etag); w.Code != http.StatusOK {
+		t.Errorf("aged status got %v, want %v", w.Code, http.StatusOK)
+	}
+}
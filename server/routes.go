@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+// GetRoutesHandler returns all routes.
+type GetRoutesHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetRoutesHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	return h.result(), nil
+}
+
+func (h GetRoutesHandler) result() GetRoutesResult {
+	return GetRoutesResult{Routes: h.p.Routes(h.client.GetRoutes())}
+}
+
+// GetRoutesResult describes the response of the GetRoutes RPC.
+type GetRoutesResult struct{ Routes []*protocol.Route }
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/mta/mtatest"
+)
+
+// liveClient returns a client that has fetched the feed fixtures from a
+// stand-in for the MTA, which the returned function closes.
+func liveClient(t testing.TB) (*mta.Client, func()) {
+	srv := mtatest.NewServer()
+	paths, err := filepath.Glob("../mta/testdata/feeds/*.pb")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no feed fixtures: %v", err)
+	}
+	now := time.Now()
+	for _, path := range paths {
+		feedID, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".pb"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := mtatest.LoadFeed(filepath.Dir(path), feedID, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv.SetFeed(feedID, b)
+	}
+	status, err := ioutil.ReadFile("../mta/testdata/serviceStatus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetServiceStatus(status)
+
+	c, err := mta.NewClient(&mta.ClientConfig{
+		StopsFilePath:     "../mta/testdata/gtfs/stops.txt",
+		TransfersFilePath: "../mta/testdata/gtfs/transfers.txt",
+		RoutesFilePath:    "../mta/testdata/gtfs/routes.txt",
+		FeedURL:           srv.FeedURL,
+		ServiceStatusURL:  srv.ServiceStatusURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Work(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !ready(c) {
+		if time.Now().After(deadline) {
+			t.Fatal("feeds and service status were not fetched from the stand-in")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return c, srv.Close
+}
+
+// ready returns whether the client has fetched the feeds and service
+// status.
+func ready(c *mta.Client) bool {
+	if _, err := c.GetServiceStatus(); err != nil {
+		return false
+	}
+	for _, feed := range c.GetFeedStatus() {
+		if feed.Fetched == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// rpcResponse is a JSON-RPC response.
+type rpcResponse struct {
+	Result json.RawMessage
+	Error  *struct{ Code int }
+}
+
+func call(h http.Handler, method, params string) *httptest.ResponseRecorder {
+	body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `"`
+	if params != "" {
+		body += `,"params":` + params
+	}
+	body += "}"
+	r := httptest.NewRequest("POST", "/rpc", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestRPC(t *testing.T) {
+	c, done := liveClient(t)
+	defer done()
+	h := New(&Params{Client: c}).handler()
+
+	var tests = []struct {
+		method string
+		params string
+		code   int
+		count  func(b []byte) int
+		min    int
+	}{
+		{"GetStations", "", 0, func(b []byte) int {
+			var v GetStationsResult
+			json.Unmarshal(b, &v)
+			return len(v.Stations)
+		}, 400},
+		{"GetStation", `{"ID":"L03"}`, 0, func(b []byte) int {
+			var v GetStationResult
+			json.Unmarshal(b, &v)
+			return len(v.Station.Arrivals["N"]) + len(v.Station.Arrivals["S"])
+		}, 1},
+		{"GetStation", `{"ID":"L03","Routes":["L"],"Directions":["S"],"Limit":2}`, 0, func(b []byte) int {
+			var v GetStationResult
+			json.Unmarshal(b, &v)
+			if len(v.Station.Arrivals["N"]) > 0 || len(v.Station.Arrivals["S"]) > 2 {
+				return 0
+			}
+			return len(v.Station.Arrivals["S"])
+		}, 1},
+		{"GetClosestStations", `{"Lat":40.7347908,"Lon":-73.9907299,"NumStations":3}`, 0, func(b []byte) int {
+			var v GetClosestResult
+			json.Unmarshal(b, &v)
+			return len(v.Stations)
+		}, 3},
+		{"GetSystemStatus", "", 0, func(b []byte) int {
+			var v GetSystemStatusResult
+			json.Unmarshal(b, &v)
+			return len(v.Service.Status)
+		}, 11},
+		{"GetRoutes", "", 0, func(b []byte) int {
+			var v GetRoutesResult
+			json.Unmarshal(b, &v)
+			return len(v.Routes)
+		}, 30},
+		{"GetHeadways", `{"RouteID":"L","StationID":"L03"}`, 0, nil, 0},
+		{"GetAnomalies", "", 0, nil, 0},
+		{"GetPlannedWork", `{"Routes":["E"],"From":"2018-06-11T00:00:00Z","To":"2018-06-18T00:00:00Z"}`, 0, func(b []byte) int {
+			var v GetPlannedWorkResult
+			json.Unmarshal(b, &v)
+			if len(v.PlannedWork) != 1 {
+				return 0
+			}
+			return len(v.PlannedWork[0].Periods)
+		}, 5},
+		{"GetPlannedWork", `{"From":"2018-06-18T00:00:00Z","To":"2018-06-11T00:00:00Z"}`, -32602, nil, 0},
+		{"GetStatusHistory", `{"RouteID":"L","Since":"2000-01-01T00:00:00Z"}`, 0, func(b []byte) int {
+			var v GetStatusHistoryResult
+			json.Unmarshal(b, &v)
+			return len(v.Changes)
+		}, 1},
+		{"GetStatusStats", `{"Routes":["L"]}`, 0, func(b []byte) int {
+			var v GetStatusStatsResult
+			json.Unmarshal(b, &v)
+			return len(v.Stats)
+		}, 1},
+		{"GetStatusStats", `{"Days":32}`, -32602, nil, 0},
+		{"ListWebhooks", "", -32600, nil, 0},
+		{"SubscribePush", `{"Stations":["L03"]}`, -32600, nil, 0},
+		{"GetStation", `{"ID":"XXX"}`, -32602, nil, 0},
+		{"GetStation", `{"ID":"L03","Directions":["E"]}`, -32602, nil, 0},
+		{"GetTrains", "", -32601, nil, 0},
+	}
+	for _, tt := range tests {
+		w := call(h, tt.method, tt.params)
+		var resp rpcResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%s %s: %v", tt.method, tt.params, err)
+			continue
+		}
+		var code int
+		if resp.Error != nil {
+			code = resp.Error.Code
+		}
+		if code != tt.code {
+			t.Errorf("%s %s: error code got %v, want %v", tt.method, tt.params, code, tt.code)
+			continue
+		}
+		if tt.count != nil {
+			if got := tt.count(resp.Result); got < tt.min {
+				t.Errorf("%s %s: got %v, want at least %v", tt.method, tt.params, got, tt.min)
+			}
+		}
+	}
+}
+
+func BenchmarkRPC(b *testing.B) {
+	c, done := liveClient(b)
+	defer done()
+	h := New(&Params{Client: c}).handler()
+
+	var benchmarks = []struct{ method, params string }{
+		{"GetStations", ""},
+		{"GetStation", `{"ID":"L03"}`},
+		{"GetClosestStations", `{"Lat":40.7347908,"Lon":-73.9907299,"NumStations":3}`},
+		{"GetSystemStatus", ""},
+		{"GetRoutes", ""},
+		{"GetHeadways", `{"RouteID":"L","StationID":"L03"}`},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.method, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if w := call(h, bm.method, bm.params); w.Code != 200 {
+					b.Fatalf("status %d", w.Code)
+				}
+			}
+		})
+	}
+}
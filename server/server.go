@@ -1,28 +1,58 @@
 package server
 
 import (
+	"context"
+	"crypto/subtle"
+	"expvar"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/pkg/metrics"
+	"github.com/jeffreylo/mtapi/pkg/webpush"
 	"github.com/jeffreylo/mtapi/server/protocol"
 	"github.com/julienschmidt/httprouter"
 	"github.com/osamingo/jsonrpc"
 )
 
+const writeTimeout = 10 * time.Second
+
 // Server is the interface to the MTA API.
 type Server struct {
 	client      *mta.Client
 	dispatcher  *jsonrpc.MethodRepository
+	dispatcher2 *jsonrpc.MethodRepository
+	rest        []*restAPI
+	stream      http.Handler
+	access      *accessControl
+	openRPC     http.Handler
+	openAPI     http.Handler
+	gtfsRT      map[string]http.Handler
+	siri        http.Handler
+	health      http.Handler
+	ready       http.Handler
+	webhooks    *webhooks
+	pushes      *pushes
 	ensureSSL   bool
 	environment string
 	port        int
 	release     string
 	staticPath  string
+
+	// metricsToken, if set, is required to read the metrics.
+	metricsToken string
+
+	// quit is closed on shutdown to end streams.
+	quit     chan struct{}
+	quitOnce sync.Once
+	mtx      sync.Mutex
+	srv      *http.Server
 }
 
 // Params defines the server dependencies.
@@ -33,24 +63,106 @@ type Params struct {
 	Port        int
 	Release     string
 	StaticPath  string
+
+	// APIKeys maps issued API keys to the names of their consumers.
+	APIKeys map[string]string
+	// KeyRate is the number of requests per second allowed per API key;
+	// zero is unlimited.
+	KeyRate float64
+	// AnonymousRate is the number of requests per second allowed per IP
+	// address without an API key; zero is unlimited.
+	AnonymousRate float64
+	// TrustProxy is set when requests come through a proxy, e.g., the
+	// Heroku router, that appends the client's address to X-Forwarded-For.
+	TrustProxy bool
+
+	// MaxFeedAge is how long a feed may go without a successful fetch
+	// before /readyz reports it stale; zero is five minutes.
+	MaxFeedAge time.Duration
+
+	// VAPID identifies the server to Web Push services; Web Push is not
+	// enabled without it.
+	VAPID *webpush.VAPID
+
+	// MetricsToken, if set, is the bearer token /metrics and /debug/vars
+	// require.
+	MetricsToken string
 }
 
 // New returns a server instance with the specified parameters.
 func New(p *Params) *Server {
+	rest := &restAPI{
+		prefix:   "/api/v1",
+		client:   p.Client,
+		started:  time.Now().UTC(),
+		stations: GetStationsHandler{client: p.Client, p: protocol.New()},
+		station:  GetStationHandler{client: p.Client, p: protocol.New()},
+		closest:  GetClosestHandler{client: p.Client, p: protocol.New()},
+		status:   GetSystemStatusHandler{client: p.Client, p: protocol.New()},
+		routes:   GetRoutesHandler{client: p.Client, p: protocol.New()},
+	}
+
+	hooks := newWebhooks(p.Client)
+	pushes := newPushes(p.Client, p.VAPID)
+
 	mr := jsonrpc.NewMethodRepository()
-	must(mr.RegisterMethod("GetSystemStatus", GetSystemStatusHandler{client: p.Client, p: protocol.New()}, nil, GetSystemStatusResult{}))
-	must(mr.RegisterMethod("GetStations", GetStationsHandler{client: p.Client, p: protocol.New()}, nil, GetStationsResult{}))
-	must(mr.RegisterMethod("GetStation", GetStationHandler{client: p.Client, p: protocol.New()}, GetStationParams{}, GetStationResult{}))
-	must(mr.RegisterMethod("GetClosestStations", GetClosestHandler{client: p.Client, p: protocol.New()}, GetClosestParams{}, GetClosestResult{}))
+	register := func(method string, h jsonrpc.Handler, params, result interface{}) {
+		must(mr.RegisterMethod(method, methodHandler{method, h}, params, result))
+	}
+	register("GetSystemStatus", rest.status, nil, GetSystemStatusResult{})
+	register("GetStations", rest.stations, nil, GetStationsResult{})
+	register("GetStation", rest.station, GetStationParams{}, GetStationResult{})
+	register("GetClosestStations", rest.closest, GetClosestParams{}, GetClosestResult{})
+	register("GetRoutes", rest.routes, nil, GetRoutesResult{})
+	register("GetHeadways", GetHeadwaysHandler{client: p.Client, p: protocol.New()}, GetHeadwaysParams{}, GetHeadwaysResult{})
+	register("GetAnomalies", GetAnomaliesHandler{client: p.Client, p: protocol.New()}, GetAnomaliesParams{}, GetAnomaliesResult{})
+	register("GetPredictionAccuracy", GetPredictionAccuracyHandler{client: p.Client, p: protocol.New()}, GetPredictionAccuracyParams{}, GetPredictionAccuracyResult{})
+	register("GetPlannedWork", GetPlannedWorkHandler{client: p.Client, p: protocol.New()}, GetPlannedWorkParams{}, GetPlannedWorkResult{})
+	register("GetStatusHistory", GetStatusHistoryHandler{client: p.Client, p: protocol.New()}, GetStatusHistoryParams{}, GetStatusHistoryResult{})
+	register("GetStatusStats", GetStatusStatsHandler{client: p.Client, p: protocol.New()}, GetStatusStatsParams{}, GetStatusStatsResult{})
+	register("CreateWebhook", CreateWebhookHandler{client: p.Client, webhooks: hooks}, CreateWebhookParams{}, CreateWebhookResult{})
+	register("ListWebhooks", ListWebhooksHandler{webhooks: hooks}, nil, ListWebhooksResult{})
+	register("DeleteWebhook", DeleteWebhookHandler{webhooks: hooks}, DeleteWebhookParams{}, DeleteWebhookResult{})
+	register("SubscribePush", SubscribePushHandler{client: p.Client, pushes: pushes}, SubscribePushParams{}, SubscribePushResult{})
+	discover := &DiscoverHandler{mr: mr, release: p.Release, url: "/rpc"}
+	register("rpc.discover", discover, nil, nil)
+
+	maxFeedAge := p.MaxFeedAge
+	if maxFeedAge == 0 {
+		maxFeedAge = defaultMaxFeedAge
+	}
 
+	rest2 := *rest
+	rest2.prefix = "/api/v2"
+	rest2.translate = toV2
+
+	quit := make(chan struct{})
 	return &Server{
 		client:      p.Client,
 		dispatcher:  mr,
+		dispatcher2: newV2Repository(mr, p.Release),
+		rest:        []*restAPI{rest, &rest2},
+		stream:      streamHandler{client: p.Client, p: protocol.New(), quit: quit},
+		access:      newAccessControl(p.APIKeys, p.KeyRate, p.AnonymousRate, p.TrustProxy),
+		openRPC:     discover,
+		openAPI:     &openAPIHandler{release: p.Release},
+		siri:        siriHandler{client: p.Client, p: protocol.New()},
+		health:      healthHandler{},
+		ready:       readyHandler{client: p.Client, maxAge: maxFeedAge},
+		webhooks:    hooks,
+		pushes:      pushes,
 		ensureSSL:   p.EnsureSSL,
 		environment: p.Environment,
 		port:        p.Port,
 		release:     p.Release,
 		staticPath:  p.StaticPath,
+		quit:        quit,
+		gtfsRT: map[string]http.Handler{
+			"trip-updates":      gtfsRealtimeHandler{client: p.Client, t: mta.TripUpdates},
+			"vehicle-positions": gtfsRealtimeHandler{client: p.Client, t: mta.VehiclePositions},
+			"alerts":            gtfsRealtimeHandler{client: p.Client, t: mta.Alerts},
+		},
+		metricsToken: p.MetricsToken,
 	}
 }
 
@@ -67,34 +179,110 @@ func ensureSSL(next http.Handler) http.Handler {
 	})
 }
 
-// Serve returns an http server.
+// bearer requires requests to carry the token, if set, in the
+// Authorization header.
+func bearer(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get("Authorization")
+		if !strings.HasPrefix(got, "Bearer ") || subtle.ConstantTimeCompare([]byte(got[7:]), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Serve listens on the port and serves the API, and notifies webhooks and
+// Web Push subscriptions, until Shutdown, when it returns
+// http.ErrServerClosed.
 func (s *Server) Serve() error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	s.mtx.Lock()
+	select {
+	case <-s.quit:
+		s.mtx.Unlock()
+		return http.ErrServerClosed
+	default:
+	}
+	s.srv = srv
+	s.mtx.Unlock()
+	go s.webhooks.run(s.quit)
+	if s.pushes.vapid != nil {
+		go s.pushes.run(s.quit)
+	}
+	return srv.ListenAndServe()
+}
+
+// handler routes the requests of the server.
+func (s *Server) handler() http.Handler {
 	m := httprouter.New()
 
-	var fileHandler, indexHandler, rpcHandler http.Handler
-	fileHandler = http.StripPrefix("/static/", http.FileServer(http.Dir(s.staticPath)))
-	indexHandler = serveTemplate(&tmplData{s.environment, s.release})
-	rpcHandler = s.dispatcher
-	if s.ensureSSL {
-		fileHandler = ensureSSL(fileHandler)
-		indexHandler = ensureSSL(indexHandler)
-		rpcHandler = ensureSSL(rpcHandler)
+	secure := func(h http.Handler) http.Handler {
+		if s.ensureSSL {
+			return ensureSSL(h)
+		}
+		return h
+	}
+	// Streams outlive any write timeout, so it is applied per handler.
+	wrap := func(h http.Handler) http.Handler {
+		return withTimeout(secure(h))
+	}
+	// api also applies access control, counting requests as method.
+	api := func(method string, h http.Handler) http.Handler {
+		return wrap(s.access.limit(method, h))
 	}
 
-	m.Handler("GET", "/static/*filepath", fileHandler)
-	m.Handler("GET", "/", indexHandler)
-	m.Handler("POST", "/rpc", rpcHandler)
+	m.Handler("GET", "/static/*filepath", wrap(http.StripPrefix("/static/", http.FileServer(http.Dir(s.staticPath)))))
+	m.Handler("GET", "/", wrap(serveTemplate(&tmplData{s.environment, s.release, s.pushes.publicKey()})))
+	m.Handler("POST", "/rpc", api("", s.dispatcher))
+	m.Handler("POST", "/v2/rpc", api("", s.dispatcher2))
+	// Without a token the metrics are public, deliberately: they hold
+	// counters, timings and the names of consumers, but no secrets.
+	m.Handler("GET", "/debug/vars", wrap(bearer(s.metricsToken, expvar.Handler())))
+	m.Handler("GET", "/metrics", wrap(bearer(s.metricsToken, metrics.Handler(metrics.Default))))
+	// Probes are made over plain HTTP from inside the platform.
+	m.Handler("GET", "/healthz", withTimeout(s.health))
+	m.Handler("GET", "/readyz", withTimeout(s.ready))
+	m.Handler("GET", "/stream", secure(s.access.limit("GET /stream", s.stream)))
+	m.Handler("GET", "/openrpc.json", wrap(s.openRPC))
+	m.Handler("GET", "/openapi.json", wrap(s.openAPI))
+	m.Handler("GET", "/siri/stop-monitoring", api("GET /siri/stop-monitoring", s.siri))
+	for name, h := range s.gtfsRT {
+		m.Handler("GET", "/gtfs-rt/"+name, api("GET /gtfs-rt/"+name, h))
+	}
+	for _, rest := range s.rest {
+		rest.register(m, api)
+	}
+	return m
+}
 
-	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", s.port),
-		Handler:      m,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+// Shutdown stops accepting connections, ends streams, webhook and push
+// deliveries and waits for the
+// requests in flight to complete until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mtx.Lock()
+	s.quitOnce.Do(func() { close(s.quit) })
+	srv := s.srv
+	s.mtx.Unlock()
+	if srv == nil {
+		return nil
 	}
-	return srv.ListenAndServe()
+	return srv.Shutdown(ctx)
+}
+
+func withTimeout(h http.Handler) http.Handler {
+	return http.TimeoutHandler(h, writeTimeout, http.StatusText(http.StatusServiceUnavailable))
 }
 
-type tmplData struct{ Environment, Release string }
+type tmplData struct{ Environment, Release, PushKey string }
 
 func serveTemplate(data *tmplData) http.HandlerFunc {
 	lp := filepath.Join("client", "templates", "index.html")
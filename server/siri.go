@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+)
+
+// siriHandler serves the arrivals of a station as a SIRI
+// StopMonitoringDelivery, taking the query parameters of MTA Bus Time:
+//
+//	GET /siri/stop-monitoring?MonitoringRef=L03&LineRef=MTA%20NYCT_Q&DirectionRef=1&MaximumStopVisits=3
+//
+// The response is JSON, or XML with ?format=xml.
+type siriHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+func (h siriHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	p, err := siriParams(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	f, err := p.filter()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	station, err := h.client.GetStation(mta.StationID(q.Get("MonitoringRef")))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	siri := h.p.StopMonitoring(station, f, time.Now().UTC())
+	if q.Get("format") == "xml" {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(xml.Header))
+		xml.NewEncoder(w).Encode(siri)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct{ Siri *protocol.Siri }{siri})
+}
+
+// siriParams translates the SIRI query parameters to arrival filters.
+func siriParams(q url.Values) (*ArrivalParams, error) {
+	var (
+		p   ArrivalParams
+		err error
+	)
+	if line := q.Get("LineRef"); line != "" {
+		p.Routes = []string{line[strings.LastIndex(line, "_")+1:]}
+	}
+	switch q.Get("DirectionRef") {
+	case "":
+	case "0":
+		p.Directions = []mta.Direction{"N"}
+	case "1":
+		p.Directions = []mta.Direction{"S"}
+	default:
+		return nil, invalidParam("DirectionRef")
+	}
+	if p.Limit, err = intParam(q, "MaximumStopVisits"); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jeffreylo/mtapi/server/protocol"
+)
+
+func TestSIRI(t *testing.T) {
+	h := siriHandler{client: client(t), p: protocol.New()}
+
+	var tests = []struct {
+		query       string
+		status      int
+		contentType string
+	}{
+		{"MonitoringRef=L03&LineRef=MTA%20NYCT_Q&DirectionRef=1&MaximumStopVisits=3", 200, "application/json"},
+		{"MonitoringRef=L03&format=xml", 200, "application/xml"},
+		{"MonitoringRef=foo", 404, ""},
+		{"MonitoringRef=L03&DirectionRef=2", 400, ""},
+		{"MonitoringRef=L03&MaximumStopVisits=x", 400, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/siri/stop-monitoring?"+tt.query, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status got %v, want %v", tt.query, w.Code, tt.status)
+			continue
+		}
+		if tt.contentType == "" {
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("%s: Content-Type got %v, want %v", tt.query, got, tt.contentType)
+		}
+		if !strings.Contains(w.Body.String(), "StopMonitoringDelivery") {
+			t.Errorf("%s: missing StopMonitoringDelivery", tt.query)
+		}
+	}
+}
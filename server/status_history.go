@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+const (
+	// defaultStatusHistoryWindow is how far back changes of service status
+	// are returned by default.
+	defaultStatusHistoryWindow = 24 * time.Hour
+	// defaultStatusStatsDays is the number of days of status statistics
+	// returned by default; changes are kept for a month.
+	defaultStatusStatsDays = 7
+	maxStatusStatsDays     = 31
+)
+
+// GetStatusHistoryHandler returns the changes of service status of the
+// lines serving a route.
+type GetStatusHistoryHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetStatusHistoryParams defines the parameters of the GetStatusHistory
+// RPC.
+type GetStatusHistoryParams struct {
+	// RouteID is optional; all lines are returned without it.
+	RouteID string
+	// Since defaults to a day ago.
+	Since *time.Time
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetStatusHistoryHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetStatusHistoryParams
+	if params != nil {
+		if err := jsonrpc.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	since := time.Now().UTC().Add(-defaultStatusHistoryWindow)
+	if p.Since != nil {
+		since = *p.Since
+	}
+	return GetStatusHistoryResult{Changes: h.p.StatusChanges(h.client.GetStatusHistory(p.RouteID, since))}, nil
+}
+
+// GetStatusHistoryResult describes the response of the GetStatusHistory
+// RPC.
+type GetStatusHistoryResult struct{ Changes []*protocol.StatusChange }
+
+// GetStatusStatsHandler returns the minutes lines spent in each status
+// category per day.
+type GetStatusStatsHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+}
+
+// GetStatusStatsParams defines the parameters of the GetStatusStats RPC.
+type GetStatusStatsParams struct {
+	Routes []string
+	// Days defaults to a week.
+	Days int
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h GetStatusStatsHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	var p GetStatusStatsParams
+	if params != nil {
+		if err := jsonrpc.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.Days == 0:
+		p.Days = defaultStatusStatsDays
+	case p.Days < 0 || p.Days > maxStatusStatsDays:
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidParams,
+			Message: "Days must be between 1 and 31",
+		}
+	}
+	stats, err := h.client.GetStatusStats(p.Routes, p.Days, time.Now().UTC())
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return GetStatusStatsResult{Stats: h.p.StatusStats(stats)}, nil
+}
+
+// GetStatusStatsResult describes the response of the GetStatusStats RPC.
+type GetStatusStatsResult struct{ Stats []*protocol.StatusStats }
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/intel-go/fastjson"
 	"github.com/jeffreylo/mtapi/mta"
@@ -17,13 +19,51 @@ type GetStationsHandler struct {
 
 // ServeJSONRPC implements the jsonrpc handler interface.
 func (h GetStationsHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	return h.result(), nil
+}
+
+func (h GetStationsHandler) result() GetStationsResult {
 	stations := h.client.GetStations()
-	return GetStationsResult{Stations: h.p.Stations(stations)}, nil
+	return GetStationsResult{Stations: h.p.Stations(stations)}
 }
 
 // GetStationsResult describes the response of the GetStations RPC.
 type GetStationsResult struct{ Stations []*protocol.Station }
 
+// ArrivalParams defines the optional arrival filters shared by the station
+// RPCs.
+type ArrivalParams struct {
+	Routes     []string
+	Directions []mta.Direction
+	// Limit is the maximum number of arrivals per direction.
+	Limit int
+	// Within is the time horizon in seconds.
+	Within int
+}
+
+func (p *ArrivalParams) filter() (*protocol.Filter, error) {
+	for _, d := range p.Directions {
+		if d != "N" && d != "S" {
+			return nil, &jsonrpc.Error{
+				Code:    jsonrpc.ErrorCodeInvalidParams,
+				Message: fmt.Sprintf("invalid direction %q", d),
+			}
+		}
+	}
+	if p.Limit < 0 || p.Within < 0 {
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidParams,
+			Message: "Limit and Within must not be negative",
+		}
+	}
+	return &protocol.Filter{
+		Routes:     p.Routes,
+		Directions: p.Directions,
+		Limit:      p.Limit,
+		Within:     time.Duration(p.Within) * time.Second,
+	}, nil
+}
+
 // GetStationHandler returns all schedules for a given station.
 type GetStationHandler struct {
 	client *mta.Client
@@ -31,7 +71,10 @@ type GetStationHandler struct {
 }
 
 // GetStationParams defines the parameters of the GetStation RPC.
-type GetStationParams struct{ ID string }
+type GetStationParams struct {
+	ID string
+	ArrivalParams
+}
 
 // ServeJSONRPC implements the jsonrpc handler interface.
 func (h GetStationHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
@@ -39,19 +82,27 @@ func (h GetStationHandler) ServeJSONRPC(c context.Context, params *fastjson.RawM
 	if err := jsonrpc.Unmarshal(params, &p); err != nil {
 		return nil, err
 	}
+	result, err := h.result(&p)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return result, nil
+}
 
+func (h GetStationHandler) result(p *GetStationParams) (*GetStationResult, error) {
+	f, err := p.filter()
+	if err != nil {
+		return nil, err
+	}
 	station, err := h.client.GetStation(mta.StationID(p.ID))
 	if err != nil {
-		return nil, &jsonrpc.Error{
-			Code:    jsonrpc.ErrorCodeInvalidParams,
-			Message: err.Error(),
-		}
+		return nil, err
 	}
-	return GetStationResult{Station: h.p.Station(station)}, nil
+	return &GetStationResult{Station: h.p.Station(station, f)}, nil
 }
 
-// GetStationResult describes the response of the GetStations RPC.
-type GetStationResult struct{ Station interface{} }
+// GetStationResult describes the response of the GetStation RPC.
+type GetStationResult struct{ Station *protocol.Station }
 
 // GetClosestHandler returns the nearest stations.
 type GetClosestHandler struct {
@@ -63,6 +114,7 @@ type GetClosestHandler struct {
 type GetClosestParams struct {
 	Lat, Lon    float64
 	NumStations int
+	ArrivalParams
 }
 
 // ServeJSONRPC implements the jsonrpc handler interface.
@@ -71,13 +123,25 @@ func (h GetClosestHandler) ServeJSONRPC(c context.Context, params *fastjson.RawM
 	if err := jsonrpc.Unmarshal(params, &p); err != nil {
 		return nil, err
 	}
+	result, err := h.result(&p)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return result, nil
+}
+
+func (h GetClosestHandler) result(p *GetClosestParams) (*GetClosestResult, error) {
+	f, err := p.filter()
+	if err != nil {
+		return nil, err
+	}
 	stations := h.client.GetClosestStations(&mta.Coordinates{Lat: p.Lat, Lon: p.Lon}, p.NumStations)
 	vv := make([]*protocol.Station, 0, len(stations))
 	for _, v := range stations {
-		vv = append(vv, h.p.Station(v))
+		vv = append(vv, h.p.Station(v, f))
 	}
-	return GetClosestResult{Stations: stations}, nil
+	return &GetClosestResult{Stations: vv}, nil
 }
 
-// GetClosestResult is the result.
-type GetClosestResult struct{ Stations interface{} }
+// GetClosestResult describes the response of the GetClosestStations RPC.
+type GetClosestResult struct{ Stations []*protocol.Station }
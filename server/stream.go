@@ -0,0 +1,158 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/pkg/errors"
+)
+
+const (
+	streamKeepAlive = 30 * time.Second
+	// maxStreamStations bounds the stations a stream subscribes to, as
+	// each is diffed on every refresh.
+	maxStreamStations = 20
+)
+
+// streamHandler streams station updates as server-sent events. Clients
+// subscribe to a list of stations or to the stations closest to a
+// location, narrowed by the same filters as the station RPCs:
+//
+//	GET /stream?stations=L03,127&routes=Q&directions=S&limit=3&within=600
+//	GET /stream?lat=40.73&lon=-73.99&n=3
+//
+// At most maxStreamStations are subscribed to. Every subscribed station is
+// sent once as a "station" event. After, only how its arrivals changed is
+// sent, as an "arrivals" event holding a protocol.StationDiff. Streams end
+// when quit is closed.
+type streamHandler struct {
+	client *mta.Client
+	p      *protocol.Protocol
+	quit   <-chan struct{}
+}
+
+func (h streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, http.StatusText(500), 500)
+		return
+	}
+	stations, f, err := h.parse(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sub := h.client.Subscribe()
+	defer h.client.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	last := make(map[mta.StationID]*protocol.Station, len(stations))
+	send := func(event string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		return err
+	}
+
+	for _, station := range stations {
+		v := h.p.Station(station, f)
+		last[station.ID] = v
+		if err := send("station", v); err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.quit:
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-sub.C:
+			updated := make(map[mta.StationID]struct{})
+			for _, id := range sub.Updated() {
+				updated[id] = struct{}{}
+			}
+			for _, station := range stations {
+				if _, ok := updated[station.ID]; !ok {
+					continue
+				}
+				// The stations are snapshots; get their arrivals anew.
+				current, err := h.client.GetStation(station.ID)
+				if err != nil {
+					continue
+				}
+				v := h.p.Station(current, f)
+				diff := protocol.Diff(last[station.ID], v)
+				if diff == nil {
+					continue
+				}
+				last[station.ID] = v
+				if err := send("arrivals", diff); err != nil {
+					return
+				}
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// parse resolves the subscribed stations and arrival filter.
+func (h streamHandler) parse(q url.Values) ([]*mta.Station, *protocol.Filter, error) {
+	p, err := arrivalParams(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := p.filter()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ids := listParam(q, "stations"); len(ids) > 0 {
+		if len(ids) > maxStreamStations {
+			return nil, nil, invalidParam("stations")
+		}
+		stations := make([]*mta.Station, 0, len(ids))
+		for _, id := range ids {
+			station, err := h.client.GetStation(mta.StationID(id))
+			if err != nil {
+				return nil, nil, errors.Wrap(err, id)
+			}
+			stations = append(stations, station)
+		}
+		return stations, f, nil
+	}
+
+	lat, lon, err := coordinates(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := intParam(q, "n")
+	if err != nil {
+		return nil, nil, err
+	}
+	if n > maxStreamStations {
+		return nil, nil, invalidParam("n")
+	}
+	return h.client.GetClosestStations(&mta.Coordinates{Lat: lat, Lon: lon}, n), f, nil
+}
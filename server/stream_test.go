@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+)
+
+func client(t *testing.T) *mta.Client {
+	client, err := mta.NewClient(&mta.ClientConfig{
+		StopsFilePath:     "../mta/testdata/gtfs/stops.txt",
+		TransfersFilePath: "../mta/testdata/gtfs/transfers.txt",
+		RoutesFilePath:    "../mta/testdata/gtfs/routes.txt",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestStream(t *testing.T) {
+	h := streamHandler{client: client(t), p: protocol.New()}
+
+	var tests = []struct {
+		query  string
+		status int
+		events int
+	}{
+		{"stations=L03,127", 200, 2},
+		{"lat=40.7347908&lon=-73.9907299&n=3", 200, 3},
+		{"stations=foo", 404, 0},
+		{"lat=40.7347908", 400, 0},
+		{"stations=L03&directions=E", 400, 0},
+		{"stations=L03&limit=x", 400, 0},
+		{"stations=" + strings.Repeat("L03,", maxStreamStations) + "127", 400, 0},
+		{"lat=40.7347908&lon=-73.9907299&n=" + strconv.Itoa(maxStreamStations+1), 400, 0},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := httptest.NewRequest("GET", "/stream?"+tt.query, nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		// The handler streams until the request is cancelled.
+		cancel()
+		h.ServeHTTP(w, r)
+
+		if w.Code != tt.status {
+			t.Errorf("%s: status got %v, want %v", tt.query, w.Code, tt.status)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("%s: Content-Type got %v", tt.query, ct)
+		}
+		events := 0
+		scanner := bufio.NewScanner(w.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			if strings.HasPrefix(scanner.Text(), "event: station") {
+				events++
+			}
+		}
+		if events != tt.events {
+			t.Errorf("%s: events got %v, want %v", tt.query, events, tt.events)
+		}
+	}
+}
@@ -14,15 +14,20 @@ type GetSystemStatusHandler struct {
 	p      *protocol.Protocol
 }
 
-type GetSystemStatusResult struct{ Service *mta.Service }
+type GetSystemStatusResult struct{ Service *protocol.Service }
 
 func (h GetSystemStatusHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	result, err := h.result()
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return result, nil
+}
+
+func (h GetSystemStatusHandler) result() (*GetSystemStatusResult, error) {
 	service, err := h.client.GetServiceStatus()
 	if err != nil {
-		return nil, &jsonrpc.Error{
-			Code:    jsonrpc.ErrorCodeInternal,
-			Message: err.Error(),
-		}
+		return nil, err
 	}
-	return GetSystemStatusResult{Service: service}, nil
+	return &GetSystemStatusResult{Service: h.p.Service(service)}, nil
 }
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/server/protocol/v2"
+	"github.com/osamingo/jsonrpc"
+)
+
+// v2Results are the result types of the methods in version 2 of the
+// protocol.
+var v2Results = map[string]interface{}{
+	"GetSystemStatus":       v2.ServiceResult{},
+	"GetStations":           v2.StationsResult{},
+	"GetStation":            v2.StationResult{},
+	"GetClosestStations":    v2.StationsResult{},
+	"GetRoutes":             v2.RoutesResult{},
+	"GetHeadways":           v2.HeadwaysResult{},
+	"GetAnomalies":          v2.AnomaliesResult{},
+	"GetPredictionAccuracy": v2.AccuracyResult{},
+	"GetPlannedWork":        v2.PlannedWorkResult{},
+	"GetStatusHistory":      v2.StatusHistoryResult{},
+	"GetStatusStats":        v2.StatusStatsResult{},
+	"CreateWebhook":         v2.CreateWebhookResult{},
+	"ListWebhooks":          v2.WebhooksResult{},
+	"DeleteWebhook":         v2.WebhookResult{},
+	"SubscribePush":         v2.PushSubscriptionResult{},
+}
+
+// newV2Repository registers the methods of mr in version 2 of the
+// protocol.
+func newV2Repository(mr *jsonrpc.MethodRepository, release string) *jsonrpc.MethodRepository {
+	mr2 := jsonrpc.NewMethodRepository()
+	for name, md := range mr.Methods() {
+		result, ok := v2Results[name]
+		if !ok {
+			continue
+		}
+		must(mr2.RegisterMethod(name, v2Handler{md.Handler}, md.Params, result))
+	}
+	must(mr2.RegisterMethod("rpc.discover", &DiscoverHandler{mr: mr2, release: release, url: "/v2/rpc"}, nil, nil))
+	return mr2
+}
+
+// v2Handler serves a method in version 2 of the protocol by translating
+// the result of its version 1 handler.
+type v2Handler struct{ h jsonrpc.Handler }
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h v2Handler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	result, err := h.h.ServeJSONRPC(c, params)
+	if err != nil {
+		return nil, err
+	}
+	return toV2(result), nil
+}
+
+// toV2 translates a version 1 result to version 2.
+func toV2(v interface{}) interface{} {
+	switch r := v.(type) {
+	case *GetSystemStatusResult:
+		return v2.ServiceResult{Service: v2.NewService(r.Service)}
+	case GetStationsResult:
+		return v2.StationsResult{Stations: v2.NewStations(r.Stations)}
+	case *GetStationResult:
+		return v2.StationResult{Station: v2.NewStation(r.Station)}
+	case *GetClosestResult:
+		return v2.StationsResult{Stations: v2.NewStations(r.Stations)}
+	case GetRoutesResult:
+		return v2.RoutesResult{Routes: v2.NewRoutes(r.Routes)}
+	case GetHeadwaysResult:
+		return v2.HeadwaysResult{Headways: v2.NewHeadways(r.Headways)}
+	case GetAnomaliesResult:
+		return v2.AnomaliesResult{Anomalies: v2.NewAnomalies(r.Anomalies)}
+	case GetPredictionAccuracyResult:
+		return v2.AccuracyResult{Accuracy: v2.NewAccuracy(r.Accuracy)}
+	case GetPlannedWorkResult:
+		return v2.PlannedWorkResult{PlannedWork: v2.NewPlannedWork(r.PlannedWork)}
+	case GetStatusHistoryResult:
+		return v2.StatusHistoryResult{Changes: v2.NewStatusChanges(r.Changes)}
+	case GetStatusStatsResult:
+		return v2.StatusStatsResult{Stats: v2.NewStatusStats(r.Stats)}
+	case CreateWebhookResult:
+		return v2.CreateWebhookResult{Webhook: v2.NewWebhook(r.Webhook), Secret: r.Secret}
+	case ListWebhooksResult:
+		return v2.WebhooksResult{Webhooks: v2.NewWebhooks(r.Webhooks)}
+	case DeleteWebhookResult:
+		return v2.WebhookResult{Webhook: v2.NewWebhook(r.Webhook)}
+	case SubscribePushResult:
+		return v2.PushSubscriptionResult{Stations: r.Stations, Routes: r.Routes}
+	}
+	return v
+}
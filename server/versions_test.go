@@ -0,0 +1,51 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jeffreylo/mtapi/server/protocol/v2"
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestV2Methods(t *testing.T) {
+	s := New(&Params{Client: client(t)})
+	methods := s.dispatcher2.Methods()
+	for name := range s.dispatcher.Methods() {
+		if _, ok := methods[name]; !ok {
+			t.Errorf("%s: missing from v2", name)
+		}
+	}
+}
+
+func TestV2(t *testing.T) {
+	s := New(&Params{Client: client(t)})
+
+	body := `{"jsonrpc":"2.0","method":"GetRoutes","id":1}`
+	r := httptest.NewRequest("POST", "/v2/rpc", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.dispatcher2.ServeHTTP(w, r)
+	var rpc struct{ Result v2.RoutesResult }
+	if err := json.Unmarshal(w.Body.Bytes(), &rpc); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(rpc.Result.Routes), 30; got != want {
+		t.Errorf("rpc: got %v, want %v", got, want)
+	}
+
+	m := httprouter.New()
+	s.rest[1].register(m, func(_ string, h http.Handler) http.Handler { return h })
+	w = httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/stations/L03", nil))
+	var rest v2.StationResult
+	if err := json.Unmarshal(w.Body.Bytes(), &rest); err != nil {
+		t.Fatal(err)
+	}
+	if rest.Station == nil || rest.Station.ID != "L03" {
+		t.Errorf("rest: got %v, want %v", rest.Station, "L03")
+	}
+}
@@ -0,0 +1,433 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+const (
+	// maxWebhooks is the number of webhooks a consumer may register.
+	maxWebhooks = 20
+	// defaultMinDelay is the delay beyond which the next train is notified
+	// by default.
+	defaultMinDelay = 5 * time.Minute
+
+	// signatureHeader carries the hex HMAC-SHA256 keyed by the webhook's
+	// secret of the timestamp, a period and the body, e.g.,
+	// "sha256=4f2a...". Signing the timestamp lets receivers refuse
+	// replayed deliveries.
+	signatureHeader = "X-Mtapi-Signature"
+	// timestampHeader carries when the event occurred in Unix seconds.
+	timestampHeader = "X-Mtapi-Timestamp"
+	eventHeader     = "X-Mtapi-Event"
+	deliveryHeader  = "X-Mtapi-Delivery"
+)
+
+// WebhookKind selects the events a webhook is notified of.
+type WebhookKind string
+
+// Webhook kinds.
+const (
+	// WebhookStatus notifies the changes of service status of the line
+	// serving a route.
+	WebhookStatus WebhookKind = "status"
+	// WebhookDelay notifies when the next train of a route at a station
+	// is delayed beyond a threshold.
+	WebhookDelay WebhookKind = "delay"
+)
+
+// webhook is a registered webhook and what it has observed.
+type webhook struct {
+	protocol.Webhook
+	consumer string
+	secret   string
+
+	// first is when each trip was first predicted to arrive at the station
+	// of a delay webhook, and notified the last trip notified as delayed.
+	first    map[string]time.Time
+	notified string
+}
+
+// webhooks holds the registered webhooks, notifying them of the events
+// they match after each refresh. Webhooks are kept in memory, so they do
+// not survive a restart.
+type webhooks struct {
+	client    *mta.Client
+	deliverer *deliverer
+
+	mtx   sync.Mutex
+	hooks map[string]*webhook
+}
+
+func newWebhooks(client *mta.Client) *webhooks {
+	return &webhooks{
+		client:    client,
+		deliverer: newDeliverer("webhook", webhookDeliveries),
+		hooks:     make(map[string]*webhook),
+	}
+}
+
+// run matches the webhooks against each refresh and delivers their events
+// until quit is closed.
+func (w *webhooks) run(quit <-chan struct{}) {
+	go w.deliverer.run(quit)
+
+	sub := w.client.SubscribeStatus()
+	defer w.client.Unsubscribe(sub)
+	for {
+		select {
+		case <-quit:
+			return
+		case <-sub.C:
+			w.match(sub.Updated(), sub.StatusChanges(), time.Now().UTC())
+		}
+	}
+}
+
+// match queues the events of the webhooks matching the stations refreshed
+// and the changes of service status.
+func (w *webhooks) match(updated []mta.StationID, changes []*mta.StatusChange, now time.Time) {
+	refreshed := make(map[mta.StationID]struct{}, len(updated))
+	for _, id := range updated {
+		refreshed[id] = struct{}{}
+	}
+	p := protocol.New()
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for _, h := range w.hooks {
+		switch WebhookKind(h.Kind) {
+		case WebhookStatus:
+			for _, v := range changes {
+				if h.RouteID != "" && !servesRoute(v.Routes, h.RouteID) {
+					continue
+				}
+				w.notify(h, &protocol.WebhookEvent{Status: p.StatusChanges([]*mta.StatusChange{v})[0]}, now)
+			}
+		case WebhookDelay:
+			if _, ok := refreshed[mta.StationID(h.StationID)]; !ok {
+				continue
+			}
+			station, err := w.client.GetStation(mta.StationID(h.StationID))
+			if err != nil {
+				continue
+			}
+			if delay := h.delayed(station.Arrivals[mta.Direction(h.Direction)], now); delay != nil {
+				w.notify(h, &protocol.WebhookEvent{Delay: delay}, now)
+			}
+		}
+	}
+}
+
+// notify queues an event for delivery to a webhook. The caller must hold
+// w.mtx.
+func (w *webhooks) notify(h *webhook, event *protocol.WebhookEvent, now time.Time) {
+	event.ID = newID(8)
+	event.Webhook = h.ID
+	event.Kind = h.Kind
+	event.Time = now
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set(eventHeader, h.Kind)
+	header.Set(deliveryHeader, event.ID)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	header.Set(timestampHeader, timestamp)
+	header.Set(signatureHeader, "sha256="+sign(h.secret, timestamp, body))
+	w.deliverer.enqueue(&delivery{id: event.ID, url: h.URL, header: header, body: body})
+}
+
+// delayed returns the delay of the next train of the webhook's route among
+// the arrivals at its station, if it newly exceeds the webhook's threshold.
+// A train is delayed by how much later it is predicted to arrive than when
+// it was first predicted.
+func (h *webhook) delayed(arrivals []*mta.Arrival, now time.Time) *protocol.Delay {
+	var next *mta.Arrival
+	seen := make(map[string]struct{})
+	for _, a := range arrivals {
+		if mta.PublicRouteID(a.RouteID) != h.RouteID || a.Time == nil || a.Stale(now) {
+			continue
+		}
+		seen[a.TripID] = struct{}{}
+		if _, ok := h.first[a.TripID]; !ok {
+			h.first[a.TripID] = *a.Time
+		}
+		if next == nil && a.Time.After(now) {
+			next = a
+		}
+	}
+	for tripID := range h.first {
+		if _, ok := seen[tripID]; !ok {
+			delete(h.first, tripID)
+		}
+	}
+	if next == nil || next.TripID == h.notified {
+		return nil
+	}
+	delay := next.Time.Sub(h.first[next.TripID])
+	if delay <= time.Duration(h.MinDelay)*time.Second {
+		return nil
+	}
+	h.notified = next.TripID
+	return &protocol.Delay{
+		RouteID:   h.RouteID,
+		TripID:    next.TripID,
+		StationID: h.StationID,
+		Direction: h.Direction,
+		Time:      *next.Time,
+		Delay:     int(delay / time.Second),
+	}
+}
+
+// add registers a webhook of a consumer.
+func (w *webhooks) add(h *webhook) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	var n int
+	for _, v := range w.hooks {
+		if v.consumer == h.consumer {
+			n++
+		}
+	}
+	if n >= maxWebhooks {
+		return fmt.Errorf("at most %d webhooks may be registered", maxWebhooks)
+	}
+	w.hooks[h.ID] = h
+	return nil
+}
+
+// list returns the webhooks of a consumer, oldest first.
+func (w *webhooks) list(consumer string) []*protocol.Webhook {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	result := make([]*protocol.Webhook, 0)
+	for _, h := range w.hooks {
+		if h.consumer == consumer {
+			v := h.Webhook
+			result = append(result, &v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Created.Equal(result[j].Created) {
+			return result[i].Created.Before(result[j].Created)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// remove unregisters a webhook of a consumer, returning it or nil if the
+// consumer has no such webhook.
+func (w *webhooks) remove(consumer, id string) *protocol.Webhook {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	h, ok := w.hooks[id]
+	if !ok || h.consumer != consumer {
+		return nil
+	}
+	delete(w.hooks, id)
+	v := h.Webhook
+	return &v
+}
+
+// servesRoute reports whether one of routes is signed as routeID, a public
+// route ID.
+func servesRoute(routes []string, routeID string) bool {
+	for _, v := range routes {
+		if mta.PublicRouteID(v) == routeID {
+			return true
+		}
+	}
+	return false
+}
+
+// sign returns the hex HMAC-SHA256 of a timestamp, a period and a body
+// keyed by a secret.
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newID returns n random bytes in hex.
+func newID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// webhookConsumer returns the consumer a webhook RPC is made by. Webhooks
+// belong to the API key that registered them, so one is required.
+func webhookConsumer(c context.Context) (string, *jsonrpc.Error) {
+	name := consumer(c)
+	if name == anonymous {
+		return "", &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidRequest,
+			Message: "webhooks require an API key",
+		}
+	}
+	return name, nil
+}
+
+// CreateWebhookHandler registers a webhook.
+type CreateWebhookHandler struct {
+	client   *mta.Client
+	webhooks *webhooks
+}
+
+// CreateWebhookParams defines the parameters of the CreateWebhook RPC.
+type CreateWebhookParams struct {
+	URL  string
+	Kind WebhookKind
+	// RouteID is optional for status webhooks. It is kept as the public
+	// route ID, so 6X watches the 6 and GS every shuttle, as riders know
+	// them.
+	RouteID string
+	// StationID and Direction are those of the trains delay webhooks
+	// watch.
+	StationID string
+	Direction string
+	// MinDelay is the number of seconds the next train may be delayed
+	// before it is notified; defaults to five minutes.
+	MinDelay int
+}
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h CreateWebhookHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	name, rpcErr := webhookConsumer(c)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var p CreateWebhookParams
+	if err := jsonrpc.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := h.validate(&p); err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.ErrorCodeInvalidParams, Message: err.Error()}
+	}
+
+	hook := &webhook{
+		Webhook: protocol.Webhook{
+			ID:      newID(8),
+			URL:     p.URL,
+			Kind:    string(p.Kind),
+			RouteID: mta.PublicRouteID(p.RouteID),
+			Created: time.Now().UTC(),
+		},
+		consumer: name,
+		secret:   newID(32),
+	}
+	if p.Kind == WebhookDelay {
+		hook.StationID = p.StationID
+		hook.Direction = p.Direction
+		hook.MinDelay = int(defaultMinDelay / time.Second)
+		if p.MinDelay > 0 {
+			hook.MinDelay = p.MinDelay
+		}
+		hook.first = make(map[string]time.Time)
+	}
+	if err := h.webhooks.add(hook); err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.ErrorCodeInvalidRequest, Message: err.Error()}
+	}
+	v := hook.Webhook
+	return CreateWebhookResult{Webhook: &v, Secret: hook.secret}, nil
+}
+
+func (h CreateWebhookHandler) validate(p *CreateWebhookParams) error {
+	if err := h.webhooks.deliverer.checkURL(p.URL, "http", "https"); err != nil {
+		return err
+	}
+	switch p.Kind {
+	case WebhookStatus:
+	case WebhookDelay:
+		if p.RouteID == "" {
+			return fmt.Errorf("RouteID is required")
+		}
+		if _, err := h.client.GetStation(mta.StationID(p.StationID)); err != nil {
+			return fmt.Errorf("invalid StationID %q", p.StationID)
+		}
+		if p.Direction != "N" && p.Direction != "S" {
+			return fmt.Errorf("invalid direction %q", p.Direction)
+		}
+		if p.MinDelay < 0 {
+			return fmt.Errorf("MinDelay must not be negative")
+		}
+	default:
+		return fmt.Errorf("invalid kind %q", p.Kind)
+	}
+	return nil
+}
+
+// CreateWebhookResult describes the response of the CreateWebhook RPC.
+// The secret signs the deliveries to the webhook and is not returned again.
+type CreateWebhookResult struct {
+	Webhook *protocol.Webhook
+	Secret  string
+}
+
+// ListWebhooksHandler returns the webhooks of the consumer.
+type ListWebhooksHandler struct{ webhooks *webhooks }
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h ListWebhooksHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	name, err := webhookConsumer(c)
+	if err != nil {
+		return nil, err
+	}
+	return ListWebhooksResult{Webhooks: h.webhooks.list(name)}, nil
+}
+
+// ListWebhooksResult describes the response of the ListWebhooks RPC.
+type ListWebhooksResult struct{ Webhooks []*protocol.Webhook }
+
+// DeleteWebhookHandler unregisters a webhook of the consumer.
+type DeleteWebhookHandler struct{ webhooks *webhooks }
+
+// DeleteWebhookParams defines the parameters of the DeleteWebhook RPC.
+type DeleteWebhookParams struct{ WebhookID string }
+
+// ServeJSONRPC implements the jsonrpc handler interface.
+func (h DeleteWebhookHandler) ServeJSONRPC(c context.Context, params *fastjson.RawMessage) (interface{}, *jsonrpc.Error) {
+	name, rpcErr := webhookConsumer(c)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var p DeleteWebhookParams
+	if err := jsonrpc.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	v := h.webhooks.remove(name, p.WebhookID)
+	if v == nil {
+		return nil, &jsonrpc.Error{
+			Code:    jsonrpc.ErrorCodeInvalidParams,
+			Message: fmt.Sprintf("no webhook %q", p.WebhookID),
+		}
+	}
+	return DeleteWebhookResult{Webhook: v}, nil
+}
+
+// DeleteWebhookResult describes the response of the DeleteWebhook RPC.
+type DeleteWebhookResult struct{ Webhook *protocol.Webhook }
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/intel-go/fastjson"
+	"github.com/jeffreylo/mtapi/mta"
+	"github.com/jeffreylo/mtapi/server/protocol"
+	"github.com/osamingo/jsonrpc"
+)
+
+func rawParams(s string) *fastjson.RawMessage {
+	v := fastjson.RawMessage(s)
+	return &v
+}
+
+func TestWebhookRPC(t *testing.T) {
+	hooks := newWebhooks(client(t))
+	create := CreateWebhookHandler{client: hooks.client, webhooks: hooks}
+	alice := context.WithValue(context.Background(), consumerKey{}, "alice")
+	bob := context.WithValue(context.Background(), consumerKey{}, "bob")
+
+	var tests = []struct {
+		c      context.Context
+		params string
+		code   jsonrpc.ErrorCode
+	}{
+		{alice, `{"URL":"https://example.com/l","Kind":"status","RouteID":"L"}`, 0},
+		{alice, `{"URL":"https://example.com/7","Kind":"delay","RouteID":"7X","StationID":"L03","Direction":"S"}`, 0},
+		{context.Background(), `{"URL":"https://example.com/l","Kind":"status"}`, jsonrpc.ErrorCodeInvalidRequest},
+		{bob, `{"URL":"ftp://example.com/l","Kind":"status"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"http://127.0.0.1:8080/l","Kind":"status"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"http://169.254.169.254/latest/meta-data","Kind":"status"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"http://[::1]/l","Kind":"status"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"http://localhost/l","Kind":"status"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"https://example.com/l","Kind":"trains"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"https://example.com/7","Kind":"delay","RouteID":"7","StationID":"XXX","Direction":"S"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"https://example.com/7","Kind":"delay","RouteID":"7","StationID":"L03","Direction":"E"}`, jsonrpc.ErrorCodeInvalidParams},
+		{bob, `{"URL":"https://example.com/7","Kind":"delay","StationID":"L03","Direction":"S"}`, jsonrpc.ErrorCodeInvalidParams},
+	}
+	var created []*protocol.Webhook
+	for _, tt := range tests {
+		result, err := create.ServeJSONRPC(tt.c, rawParams(tt.params))
+		switch {
+		case tt.code != 0 && (err == nil || err.Code != tt.code):
+			t.Errorf("%s: got %v, want code %v", tt.params, err, tt.code)
+		case tt.code == 0 && err != nil:
+			t.Errorf("%s: got %v", tt.params, err)
+		case err == nil:
+			r := result.(CreateWebhookResult)
+			if len(r.Secret) != 64 {
+				t.Errorf("%s: Secret got %q", tt.params, r.Secret)
+			}
+			created = append(created, r.Webhook)
+		}
+	}
+	if got, want := created[1].MinDelay, 300; got != want {
+		t.Errorf("MinDelay got %v, want %v", got, want)
+	}
+	if got, want := created[1].RouteID, "7"; got != want {
+		t.Errorf("RouteID got %v, want %v", got, want)
+	}
+
+	list := ListWebhooksHandler{webhooks: hooks}
+	for _, tt := range []struct {
+		c    context.Context
+		want int
+	}{{alice, 2}, {bob, 0}} {
+		result, err := list.ServeJSONRPC(tt.c, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.(ListWebhooksResult).Webhooks; len(got) != tt.want {
+			t.Errorf("%v: got %v webhooks, want %v", consumer(tt.c), len(got), tt.want)
+		}
+	}
+
+	del := DeleteWebhookHandler{webhooks: hooks}
+	params := rawParams(`{"WebhookID":"` + created[0].ID + `"}`)
+	if _, err := del.ServeJSONRPC(bob, params); err == nil || err.Code != jsonrpc.ErrorCodeInvalidParams {
+		t.Errorf("delete by another consumer: got %v", err)
+	}
+	if _, err := del.ServeJSONRPC(alice, params); err != nil {
+		t.Errorf("delete: got %v", err)
+	}
+	if got := hooks.list("alice"); len(got) != 1 || got[0].ID != created[1].ID {
+		t.Errorf("after delete got %v, want %v", got, created[1:])
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan request, 10)
+	var n int
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{r.Header, body}
+		// The first attempt fails and is retried.
+		if n++; n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer receiver.Close()
+
+	hooks := newWebhooks(client(t))
+	// The receiver is on the loopback interface.
+	hooks.deliverer.allowed = anyIP
+	hooks.deliverer.retry = time.Millisecond
+	quit := make(chan struct{})
+	defer close(quit)
+	hooks.deliverer.run(quit)
+	for _, h := range []*webhook{
+		{Webhook: protocol.Webhook{ID: "q", URL: receiver.URL, Kind: "status", RouteID: "Q"}, secret: "secret"},
+		{Webhook: protocol.Webhook{ID: "l", URL: receiver.URL, Kind: "status", RouteID: "L"}, secret: "secret"},
+	} {
+		if err := hooks.add(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now().UTC()
+	hooks.match(nil, []*mta.StatusChange{{
+		Line:     "NQR",
+		Routes:   []string{"N", "Q", "R"},
+		Category: mta.StatusDelays,
+		Previous: mta.StatusGoodService,
+		Time:     now,
+	}}, now)
+
+	var last request
+	for i := 0; i < 2; i++ {
+		select {
+		case last = <-requests:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %v requests, want 2", i)
+		}
+	}
+	timestamp := last.header.Get(timestampHeader)
+	if got, want := timestamp, strconv.FormatInt(now.Unix(), 10); got != want {
+		t.Errorf("timestamp got %v, want %v", got, want)
+	}
+	if got, want := last.header.Get(signatureHeader), "sha256="+sign("secret", timestamp, last.body); got != want {
+		t.Errorf("signature got %v, want %v", got, want)
+	}
+	if got, want := last.header.Get(eventHeader), "status"; got != want {
+		t.Errorf("event got %v, want %v", got, want)
+	}
+	var event protocol.WebhookEvent
+	if err := json.Unmarshal(last.body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Webhook != "q" || event.Status == nil || event.Status.Category != "delays" || event.ID != last.header.Get(deliveryHeader) {
+		t.Errorf("got %+v", event)
+	}
+	select {
+	case r := <-requests:
+		t.Errorf("unexpected request %s", r.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestServesRoute(t *testing.T) {
+	var tests = []struct {
+		routes  []string
+		routeID string
+		want    bool
+	}{
+		{[]string{"N", "Q", "R"}, "Q", true},
+		{[]string{"N", "Q", "R"}, "L", false},
+		{[]string{"4", "5", "6", "6X"}, "6", true},
+		{[]string{"GS", "FS", "H"}, "S", true},
+		{[]string{"GS", "FS", "H"}, "GS", false},
+	}
+	for _, tt := range tests {
+		if got := servesRoute(tt.routes, tt.routeID); got != tt.want {
+			t.Errorf("servesRoute(%v, %q) got %v, want %v", tt.routes, tt.routeID, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookDelay(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(minutes int) *time.Time {
+		v := now.Add(time.Duration(minutes) * time.Minute)
+		return &v
+	}
+	h := &webhook{
+		Webhook: protocol.Webhook{RouteID: "7", StationID: "710", Direction: "S", MinDelay: 300},
+		first:   make(map[string]time.Time),
+	}
+	var tests = []struct {
+		arrivals []*mta.Arrival
+		want     int
+	}{
+		{[]*mta.Arrival{{TripID: "a", RouteID: "7", Time: at(2)}, {TripID: "b", RouteID: "7", Time: at(8)}}, 0},
+		// b is later, but a is next.
+		{[]*mta.Arrival{{TripID: "a", RouteID: "7", Time: at(4)}, {TripID: "b", RouteID: "7", Time: at(20)}}, 0},
+		{[]*mta.Arrival{{TripID: "x", RouteID: "6", Time: at(1)}, {TripID: "a", RouteID: "7", Time: at(8)}, {TripID: "b", RouteID: "7", Time: at(20)}}, 6 * 60},
+		// a was notified already.
+		{[]*mta.Arrival{{TripID: "a", RouteID: "7", Time: at(9)}, {TripID: "b", RouteID: "7", Time: at(20)}}, 0},
+		// a has left, so b, an express, is next.
+		{[]*mta.Arrival{{TripID: "b", RouteID: "7X", Time: at(20)}}, 12 * 60},
+	}
+	for i, tt := range tests {
+		var got int
+		if v := h.delayed(tt.arrivals, now); v != nil {
+			got = v.Delay
+		}
+		if got != tt.want {
+			t.Errorf("%d: got %v, want %v", i, got, tt.want)
+		}
+	}
+	if len(h.first) != 1 {
+		t.Errorf("first got %v, want b only", h.first)
+	}
+}